@@ -0,0 +1,25 @@
+// Package analyzer exports the doculint analyzer for drivers other than the doculint
+// command, such as Bazel's nogo, golangci-lint plugins, or multichecker binaries.
+//
+// Analyzer holds no state shared with the other analyzers of the process, such as those
+// of the library's Linter: its flags, the files named by -config, -header, and
+// -dictionary, and its cache of the reachability of URLs are its own. Only the checks
+// added with doculint.Register, from init functions, are run by every analyzer. The
+// documentation of imports is not part of that state either: a fact records whether the
+// exported declarations of a package are documented, which drivers serialize and pass
+// to the analysis of the packages importing it, so packages can be analyzed in separate
+// processes. Its flags are those of the doculint command, set through Analyzer.Flags
+// or, with nogo, through the analyzer_flags of its configuration.
+package analyzer
+
+import (
+	"github.com/george-e-shaw-iv/doculint/internal/doculint"
+	"golang.org/x/tools/go/analysis"
+)
+
+// Analyzer is the doculint analyzer, with the default settings of the doculint command.
+var Analyzer = doculint.New().Analyzer
+
+// Analyzers is the set of analyzers making up doculint, for drivers taking a list of
+// analyzers such as multichecker.Main.
+var Analyzers = []*analysis.Analyzer{Analyzer}
@@ -0,0 +1,30 @@
+package doculint
+
+import "github.com/george-e-shaw-iv/doculint/internal/doculint"
+
+// Rule describes a check performed by the analyzer, identified by an ID such as DL004.
+// The rules of registered checks should use an ID prefix of their own, such as ORG001,
+// and may set DocURL to link their issues to their documentation.
+type Rule = doculint.Rule
+
+// Check is a documentation check added to the analyzer, such as an organization
+// specific convention. Checks run during the traversal of the files of every package
+// analyzed, and their issues go through the same suppressions, confidence filtering,
+// and de-duplication as those of the rules of the analyzer.
+type Check = doculint.Check
+
+// ConfigurableCheck is a check taking settings from the configuration file given
+// through -config, found in its "checks" object under the ID of the rule of the check.
+type ConfigurableCheck = doculint.ConfigurableCheck
+
+// ReportFunc reports an issue spanning a range, such as the declaration or comment in
+// question, under the rule of the check it is given to.
+type ReportFunc = doculint.ReportFunc
+
+// Register adds c to the checks run by the analyzer, in every driver of the process,
+// including Run, Lint, and the analyzer package. It is meant to be called from init
+// functions, before any package is analyzed, and panics if the ID of the rule of c is
+// empty or already used by another rule.
+func Register(c Check) {
+	doculint.RegisterCheck(c)
+}
@@ -0,0 +1,400 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"hash"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// cacheLoadMode is the mode packages are listed with to compute their cache keys, which
+// only runs the build system's query tool rather than parsing and type checking them.
+const cacheLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+	packages.NeedImports | packages.NeedDeps | packages.NeedModule
+
+// resultCache stores the issues found in packages on disk, keyed by the contents of the
+// files of the packages and their dependencies, the doculint binary, and the flags and
+// files configuring the analyzer, so that packages that did not change since a previous
+// run are not loaded and analyzed again.
+type resultCache struct {
+	// dir is the directory holding the entries of the cache.
+	dir string
+
+	// salt is the hash of the doculint binary and of its configuration, which is part
+	// of every key.
+	salt []byte
+}
+
+// cacheEntry is the content of the file storing the issues of a package.
+type cacheEntry struct {
+	// Issues are the issues reported for the package, before they are filtered by the
+	// files given on the command line.
+	Issues []cachedIssue `json:"issues"`
+}
+
+// cachedIssue is an issue as stored in the cache, along with the fields of issue that
+// are not part of its JSON output.
+type cachedIssue struct {
+	// Issue is the issue itself.
+	Issue issue `json:"issue"`
+
+	// Position and End are the resolved range of the issue.
+	Position token.Position `json:"position"`
+	End      token.Position `json:"end"`
+
+	// Related are the resolved ranges of the related locations of the issue, in order.
+	Related []cachedRange `json:"related,omitempty"`
+
+	// Edits are the edits of the first suggested fix of the issue.
+	Edits []cachedEdit `json:"edits,omitempty"`
+}
+
+// cachedRange is the resolved range of a related location of an issue.
+type cachedRange struct {
+	// Position and End are the start and end of the range, End being invalid if the
+	// location is a single position.
+	Position token.Position `json:"position"`
+	End      token.Position `json:"end"`
+}
+
+// cachedEdit is a text edit of the suggested fix of an issue.
+type cachedEdit struct {
+	// File is the name of the file the edit applies to.
+	File string `json:"file"`
+
+	// Start and End are the byte offsets of the text replaced by the edit.
+	Start int `json:"start"`
+	End   int `json:"end"`
+
+	// Text is the replacement text.
+	Text []byte `json:"text"`
+}
+
+// openCache opens the cache stored in dir, or in the doculint directory of the user's
+// cache directory if dir is empty, creating it if needed.
+func openCache(dir string) (*resultCache, error) {
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("cache: %w", err)
+		}
+		dir = filepath.Join(base, "doculint")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cache: %w", err)
+	}
+
+	salt, err := cacheSalt()
+	if err != nil {
+		return nil, fmt.Errorf("cache: %w", err)
+	}
+
+	return &resultCache{dir: dir, salt: salt}, nil
+}
+
+// cacheSalt hashes the doculint binary, which changes with the version of the analyzer
+// and of the rules registered with it, along with the values of the flags of the
+// analyzer and the contents of the files they name, such as the configuration file.
+func cacheSalt() ([]byte, error) {
+	h := sha256.New()
+
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	if err := hashFile(h, exe); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(h, "test=%t\x00", *includeTests)
+	linter.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		value := f.Value.String()
+		fmt.Fprintf(h, "%s=%s\x00", f.Name, value)
+
+		if info, err := os.Stat(value); err == nil && info.Mode().IsRegular() {
+			// The value names a file read by the analyzer, a missing one being reported
+			// by the analyzer itself.
+			_ = hashFile(h, value)
+		}
+	})
+
+	return h.Sum(nil), nil
+}
+
+// hashFile writes the contents of the file with the given name to h.
+func hashFile(h hash.Hash, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(h, f)
+	return err
+}
+
+// keys returns the cache keys of pkgs and of their dependencies, keyed by package ID.
+// The key of a package hashes the files of its directory, since some rules read files
+// other than its Go files such as its README, its other files, and the keys of its
+// imports. Packages of module dependencies are identified by the version of their
+// module instead, which is immutable.
+func (c *resultCache) keys(pkgs []*packages.Package) map[string]string {
+	keys := make(map[string]string)
+
+	var visit func(pkg *packages.Package) string
+	visit = func(pkg *packages.Package) string {
+		if key, ok := keys[pkg.ID]; ok {
+			return key
+		}
+
+		h := sha256.New()
+		h.Write(c.salt)
+		fmt.Fprintf(h, "%s\x00", pkg.ID)
+
+		if mod := pkg.Module; mod != nil && !mod.Main && mod.Version != "" && mod.Replace == nil {
+			fmt.Fprintf(h, "%s@%s\x00", mod.Path, mod.Version)
+		} else {
+			hashPackageFiles(h, pkg)
+		}
+
+		paths := make([]string, 0, len(pkg.Imports))
+		for path := range pkg.Imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			fmt.Fprintf(h, "%s=%s\x00", path, visit(pkg.Imports[path]))
+		}
+
+		key := hex.EncodeToString(h.Sum(nil))
+		keys[pkg.ID] = key
+		return key
+	}
+
+	for _, pkg := range pkgs {
+		visit(pkg)
+	}
+
+	return keys
+}
+
+// hashPackageFiles writes the names and contents of the files of the directory of pkg
+// and of its files outside of it, such as those generated by cgo, to h. Files that
+// cannot be read are hashed by name only.
+func hashPackageFiles(h hash.Hash, pkg *packages.Package) {
+	names := make(map[string]bool)
+	if pkg.Dir != "" {
+		entries, _ := os.ReadDir(pkg.Dir)
+		for _, entry := range entries {
+			if entry.Type().IsRegular() {
+				names[filepath.Join(pkg.Dir, entry.Name())] = true
+			}
+		}
+	}
+
+	for _, files := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles, pkg.OtherFiles, pkg.EmbedFiles} {
+		for _, name := range files {
+			names[name] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		fmt.Fprintf(h, "%s\x00", name)
+		_ = hashFile(h, name)
+		h.Write([]byte{0})
+	}
+}
+
+// path returns the path of the file storing the entry with the given key.
+func (c *resultCache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+// get returns the issues stored under key, and whether there were any.
+func (c *resultCache) get(key string) ([]issue, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		// Entries written by older versions are missed.
+		return nil, false
+	}
+
+	issues := make([]issue, 0, len(entry.Issues))
+	for _, ci := range entry.Issues {
+		is := ci.Issue
+		is.position = ci.Position
+		is.end = ci.End
+
+		for i := range is.Related {
+			if i < len(ci.Related) {
+				is.Related[i].position = ci.Related[i].Position
+				is.Related[i].end = ci.Related[i].End
+			}
+		}
+
+		for _, e := range ci.Edits {
+			is.edits = append(is.edits, edit{file: e.File, start: e.Start, end: e.End, text: e.Text})
+		}
+
+		issues = append(issues, is)
+	}
+
+	return issues, true
+}
+
+// put stores issues under key. The entry is written to a temporary file renamed into
+// place, so that concurrent runs never read a partial entry.
+func (c *resultCache) put(key string, issues []issue) error {
+	entry := cacheEntry{Issues: make([]cachedIssue, 0, len(issues))}
+	for _, is := range issues {
+		ci := cachedIssue{Issue: is, Position: is.position, End: is.end}
+		for _, rel := range is.Related {
+			ci.Related = append(ci.Related, cachedRange{Position: rel.position, End: rel.end})
+		}
+		for _, e := range is.edits {
+			ci.Edits = append(ci.Edits, cachedEdit{File: e.file, Start: e.start, End: e.end, Text: e.text})
+		}
+		entry.Issues = append(entry.Issues, ci)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// analyze returns the issues of the packages matching args that are kept by the files
+// given in args, taken from the cache for the packages that did not change and found by
+// loading and analyzing the others in batches of up to -max-files Go files, whose issues
+// are then stored. Packages with errors are never stored, so that their errors are
+// printed on every run. The returned code is exitError if packages had errors, exitOK
+// otherwise, and the returned error is only set if packages could not be listed or
+// loaded.
+func (c *resultCache) analyze(args []string) ([]issue, int, error) {
+	patterns, files, err := resolveArgs("", args)
+	if err != nil {
+		return nil, exitError, err
+	}
+
+	listed, err := packages.Load(&packages.Config{
+		Mode:  cacheLoadMode,
+		Tests: *includeTests,
+	}, patterns...)
+	if err != nil {
+		return nil, exitError, err
+	}
+
+	if len(listed) == 0 {
+		return nil, exitError, fmt.Errorf("%v matched no packages", args)
+	}
+
+	keys := c.keys(listed)
+
+	var issues []issue
+	var missed []string
+	seen := make(map[string]bool)
+	for _, pkg := range listed {
+		cached, ok := c.get(keys[pkg.ID])
+		if ok {
+			issues = append(issues, files.filter(pkg, cached)...)
+			continue
+		}
+
+		if pkg.PkgPath == "command-line-arguments" {
+			// Packages made of files outside of any module can only be loaded through
+			// the original patterns.
+			missed = patterns
+			break
+		}
+
+		if !seen[pkg.PkgPath] {
+			seen[pkg.PkgPath] = true
+			missed = append(missed, pkg.PkgPath)
+		}
+	}
+
+	if len(missed) == 0 {
+		return mergeIssues(issues), exitOK, nil
+	}
+
+	var errs []error
+	failed, err := analyzeBatches(missed, func(graph *checker.Graph) {
+		for _, act := range graph.Roots {
+			if act.Err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", act.Package.ID, act.Err))
+				continue
+			}
+
+			found := packageIssues(act)
+			if key, ok := keys[act.Package.ID]; ok && len(act.Package.Errors) == 0 {
+				if err := c.put(key, found); err != nil {
+					log.Print(err)
+				}
+			}
+
+			issues = append(issues, files.filter(act.Package, found)...)
+		}
+	})
+	if err != nil {
+		return nil, exitError, err
+	}
+
+	code := exitOK
+	if failed {
+		code = exitError
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		log.Print(err)
+		code = exitError
+	}
+
+	return mergeIssues(issues), code, nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// changedLoadMode is the mode packages are listed with to find those affected by
+// changed files along with their dependencies, which only runs the build system's query
+// tool.
+const changedLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps
+
+// gitChangedDirs returns the absolute paths of the directories of the files added,
+// modified, deleted, or renamed in the working tree of the git repository containing
+// the working directory relative to the revision rev, untracked files included.
+func gitChangedDirs(rev string) (map[string]bool, error) {
+	root, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root = strings.TrimSpace(root)
+
+	// Renames are listed as a deletion and an addition, so that the packages of both
+	// directories are affected.
+	changed, err := git("-C", root, "diff", "--name-only", "--no-renames", "-z", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := git("-C", root, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make(map[string]bool)
+	for _, name := range strings.Split(changed+untracked, "\x00") {
+		if name != "" {
+			dirs[filepath.Dir(filepath.Join(root, filepath.FromSlash(name)))] = true
+		}
+	}
+
+	return dirs, nil
+}
+
+// changedPackages returns the directories of the packages matching args that are
+// affected by the changes of the working tree relative to the revision rev: those
+// whose directory holds a changed file, such as a Go file or the README checked with
+// -readme, and the packages importing them directly or through other packages, whose
+// findings depend on the names and documentation of their imports. Files given in args
+// are replaced by their packages.
+func changedPackages(args []string, rev string) ([]string, error) {
+	changed, err := gitChangedDirs(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns, _, err := resolveArgs("", args)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:  changedLoadMode,
+		Tests: *includeTests,
+	}, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The packages are visited after their imports, so that whether a package is
+	// affected is known before its importers are visited.
+	modified := make(map[*packages.Package]bool)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if changed[pkg.Dir] {
+			modified[pkg] = true
+			return
+		}
+
+		for _, imp := range pkg.Imports {
+			if modified[imp] {
+				modified[pkg] = true
+				return
+			}
+		}
+	})
+
+	affected := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if pkg.Dir != "" && modified[pkg] {
+			affected[pkg.Dir] = true
+		}
+	}
+
+	dirs := make([]string, 0, len(affected))
+	for dir := range affected {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	return dirs, nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/george-e-shaw-iv/doculint/docs"
+	"github.com/george-e-shaw-iv/doculint/internal/doculint"
+)
+
+// codeClimateRemediationPoints is the estimated effort to fix a documentation issue, in
+// the remediation points of Code Climate, where 50000 points are a trivial fix.
+const codeClimateRemediationPoints = 50000
+
+// codeClimateIssue is an issue in the Code Climate engine format, see
+// https://github.com/codeclimate/platform/blob/master/spec/analyzers/SPEC.md.
+type codeClimateIssue struct {
+	// Type is always "issue".
+	Type string `json:"type"`
+
+	// CheckName is the ID of the rule that reported the issue.
+	CheckName string `json:"check_name"`
+
+	// Description is the human readable description of the issue.
+	Description string `json:"description"`
+
+	// Content explains the rule that reported the issue, if documented.
+	Content *codeClimateContent `json:"content,omitempty"`
+
+	// Categories are always Clarity, since the rules check documentation.
+	Categories []string `json:"categories"`
+
+	// Location is where the issue was found.
+	Location codeClimateLocation `json:"location"`
+
+	// RemediationPoints is the estimated effort to fix the issue.
+	RemediationPoints int `json:"remediation_points"`
+
+	// Severity is minor for the issues of high confidence rules, info otherwise.
+	Severity string `json:"severity"`
+
+	// Fingerprint identifies the issue across runs, even if lines are added above it.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// codeClimateContent is the markdown explanation of an issue.
+type codeClimateContent struct {
+	// Body is the explanation of the rule that reported the issue.
+	Body string `json:"body"`
+}
+
+// codeClimateLocation is a range of a file.
+type codeClimateLocation struct {
+	// Path is the path of the file, relative to the working directory when possible.
+	Path string `json:"path"`
+
+	// Positions is the range of the file.
+	Positions codeClimatePositions `json:"positions"`
+}
+
+// codeClimatePositions is a range of a file. Issues reported at a single position begin
+// and end at it.
+type codeClimatePositions struct {
+	// Begin and End are the first and last positions of the range.
+	Begin codeClimatePosition `json:"begin"`
+	End   codeClimatePosition `json:"end"`
+}
+
+// codeClimatePosition is a one-based line and column of a file.
+type codeClimatePosition struct {
+	// Line is the one-based line of the position.
+	Line int `json:"line"`
+
+	// Column is the one-based byte offset of the position in its line.
+	Column int `json:"column"`
+}
+
+// writeCodeClimate writes issues to w as a JSON array of Code Climate issues, the code
+// quality report read by GitLab and Qlty.
+func writeCodeClimate(w io.Writer, issues []issue) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(codeClimateIssues(issues))
+}
+
+// writeCodeClimateEngine writes issues to w as a stream of Code Climate issues, each
+// terminated by a NUL byte, which is the output of a Code Climate engine.
+func writeCodeClimateEngine(w io.Writer, issues []issue) error {
+	for _, ci := range codeClimateIssues(issues) {
+		data, err := json.Marshal(ci)
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(append(data, 0)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// codeClimateIssues converts issues to Code Climate issues. Issues without a position
+// are skipped, since Code Climate attaches every issue to a file.
+func codeClimateIssues(issues []issue) []codeClimateIssue {
+	converted := []codeClimateIssue{}
+
+	// Fingerprints leave line numbers out so that they are stable as code moves, issues
+	// with the same rule and message in a file being told apart by their order.
+	occurrences := make(map[string]int)
+
+	for i := range issues {
+		is := &issues[i]
+		if !is.position.IsValid() {
+			continue
+		}
+
+		path := relativePath(is.position.Filename)
+
+		severity := "info"
+		if is.Confidence == doculint.ConfidenceHigh.String() {
+			severity = "minor"
+		}
+
+		end := is.position
+		if is.end.IsValid() && is.end.Filename == is.position.Filename {
+			end = is.end
+		}
+
+		key := is.Rule + "\x00" + path + "\x00" + is.Message
+		occurrences[key]++
+		sum := md5.Sum(fmt.Appendf(nil, "%s\x00%d", key, occurrences[key]))
+
+		ci := codeClimateIssue{
+			Type:        "issue",
+			CheckName:   is.Rule,
+			Description: is.Message,
+			Categories:  []string{"Clarity"},
+			Location: codeClimateLocation{
+				Path: path,
+				Positions: codeClimatePositions{
+					Begin: codeClimatePosition{Line: is.position.Line, Column: is.position.Column},
+					End:   codeClimatePosition{Line: end.Line, Column: end.Column},
+				},
+			},
+			RemediationPoints: codeClimateRemediationPoints,
+			Severity:          severity,
+			Fingerprint:       hex.EncodeToString(sum[:]),
+		}
+
+		if text, ok := docs.Explain(is.Rule); ok {
+			ci.Content = &codeClimateContent{Body: text + "\n\nSee " + is.URL}
+		}
+
+		converted = append(converted, ci)
+	}
+
+	return converted
+}
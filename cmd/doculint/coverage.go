@@ -0,0 +1,147 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// coverage is the documentation coverage of a package, which is how many of the
+// declarations doculint requires a comment for have one.
+type coverage struct {
+	// Package is the import path of the package.
+	Package string `json:"package"`
+
+	// Documented is the number of declarations that have a comment.
+	Documented int `json:"documented"`
+
+	// Total is the number of declarations that require a comment.
+	Total int `json:"total"`
+
+	// Percent is Documented as a percentage of Total.
+	Percent float64 `json:"percent"`
+}
+
+// measureCoverage measures the documentation coverage of pkgs, ordered by import path.
+// Test variants of a package are merged into the package itself and every file is
+// only counted once.
+func measureCoverage(pkgs []*packages.Package) []coverage {
+	byPath := make(map[string]*coverage)
+	hasPackageDoc := make(map[string]bool)
+	seen := make(map[string]bool)
+
+	for _, pkg := range pkgs {
+		if strings.HasSuffix(pkg.PkgPath, ".test") {
+			// Generated test main packages.
+			continue
+		}
+
+		c, ok := byPath[pkg.PkgPath]
+		if !ok {
+			c = &coverage{Package: pkg.PkgPath}
+			byPath[pkg.PkgPath] = c
+		}
+
+		for _, file := range pkg.Syntax {
+			name := pkg.Fset.Position(file.Package).Filename
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			if file.Doc != nil {
+				hasPackageDoc[pkg.PkgPath] = true
+			}
+
+			documented, total := countDocumented(pkg.Name, file)
+			c.Documented += documented
+			c.Total += total
+		}
+	}
+
+	result := make([]coverage, 0, len(byPath))
+	for path, c := range byPath {
+		// Every package needs a package comment, except for main packages.
+		if !strings.HasSuffix(path, "_test") && !isMainPackage(pkgs, path) {
+			c.Total++
+			if hasPackageDoc[path] {
+				c.Documented++
+			}
+		}
+
+		if c.Total > 0 {
+			c.Percent = float64(c.Documented) / float64(c.Total) * 100
+		}
+
+		result = append(result, *c)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Package < result[j].Package
+	})
+
+	return result
+}
+
+// isMainPackage reports whether the package with the given import path in pkgs is a
+// main package.
+func isMainPackage(pkgs []*packages.Package, path string) bool {
+	for _, pkg := range pkgs {
+		if pkg.PkgPath == path {
+			return pkg.Name == "main"
+		}
+	}
+
+	return false
+}
+
+// countDocumented returns how many of the function, type, and constant declarations in
+// file that doculint requires a comment for have one, and how many there are in total.
+func countDocumented(pkgName string, file *ast.File) (documented, total int) {
+	count := func(doc *ast.CommentGroup) {
+		total++
+		if doc != nil {
+			documented++
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Name.Name == "init" || (pkgName == "main" && decl.Name.Name == "main") {
+				continue
+			}
+
+			count(decl.Doc)
+		case *ast.GenDecl:
+			if decl.Tok != token.CONST && decl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range decl.Specs {
+				doc := decl.Doc
+				switch spec := spec.(type) {
+				case *ast.ValueSpec:
+					if decl.Lparen.IsValid() {
+						doc = spec.Doc
+					}
+
+					for range spec.Names {
+						count(doc)
+					}
+				case *ast.TypeSpec:
+					if decl.Lparen.IsValid() {
+						doc = spec.Doc
+					}
+
+					count(doc)
+				}
+			}
+		}
+	}
+
+	return documented, total
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hunkPattern matches the header of a hunk of a unified diff, capturing the optional
+// line count of the old side, and the first line and optional line count of the new
+// side.
+var hunkPattern = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// lineRange is an inclusive range of one-based line numbers.
+type lineRange struct {
+	// first and last are the first and last lines of the range.
+	first, last int
+}
+
+// changedLines maps the absolute paths of files to the ranges of their lines that were
+// added or modified, as read from a unified diff.
+type changedLines map[string][]lineRange
+
+// parseDiff reads the unified diff in r and returns the lines it adds or modifies. The
+// paths of the new side of the diff, stripped of their "b/" prefix and unquoted if git
+// quoted them, are resolved relative to root. Deleted files are ignored. Lines of hunks
+// are counted from the headers of the hunks, so that added lines beginning with "++ "
+// are not taken for the header of a file.
+func parseDiff(r io.Reader, root string) (changedLines, error) {
+	changed := make(changedLines)
+
+	var file string
+	var oldLeft, newLeft int
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+			case strings.HasPrefix(line, `\`):
+				// "\ No newline at end of file" belongs to neither side.
+			default:
+				oldLeft--
+				newLeft--
+			}
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(line, "+++ "); ok {
+			name, err := headerPath(name)
+			if err != nil {
+				return nil, err
+			}
+
+			file = ""
+			if name != "/dev/null" {
+				file = filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(name, "b/")))
+			}
+			continue
+		}
+
+		m := hunkPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		oldLeft = 1
+		if m[1] != "" {
+			oldLeft, _ = strconv.Atoi(m[1])
+		}
+
+		first, _ := strconv.Atoi(m[2])
+		newLeft = 1
+		if m[3] != "" {
+			newLeft, _ = strconv.Atoi(m[3])
+		}
+
+		// Hunks only removing lines have no lines on the new side.
+		if file != "" && newLeft > 0 {
+			changed[file] = append(changed[file], lineRange{first, first + newLeft - 1})
+		}
+	}
+
+	return changed, scanner.Err()
+}
+
+// headerPath returns the path of the header of a file in a diff, without the tab and
+// timestamp that may follow it. Paths holding special characters, which git quotes
+// and escapes as Go string literals are, such as "b/na\303\257ve.go", are unquoted.
+func headerPath(header string) (string, error) {
+	if !strings.HasPrefix(header, `"`) {
+		name, _, _ := strings.Cut(header, "\t")
+		return name, nil
+	}
+
+	quoted, err := strconv.QuotedPrefix(header)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %s in diff: %w", header, err)
+	}
+
+	return strconv.Unquote(quoted)
+}
+
+// readDiff returns the lines added or modified by the unified diff in the file at path,
+// or read from stdin if path is "-". Paths in the diff are relative to the working
+// directory.
+func readDiff(path string) (changedLines, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	if path == "-" {
+		return parseDiff(os.Stdin, wd)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseDiff(f, wd)
+}
+
+// gitChangedLines returns the lines added or modified in the working tree of the git
+// repository containing the working directory relative to the revision rev. Untracked
+// files, which git diff does not list, are considered changed in full.
+func gitChangedLines(rev string) (changedLines, error) {
+	root, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root = strings.TrimSpace(root)
+
+	diff, err := git("-C", root, "diff", "--unified=0", "--no-color", "--no-ext-diff", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := parseDiff(strings.NewReader(diff), root)
+	if err != nil {
+		return nil, err
+	}
+
+	// The names are separated by NUL bytes rather than quoted.
+	untracked, err := git("-C", root, "ls-files", "-z", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range strings.Split(untracked, "\x00") {
+		if name == "" {
+			continue
+		}
+
+		changed[filepath.Join(root, filepath.FromSlash(name))] = []lineRange{{1, math.MaxInt}}
+	}
+
+	return changed, nil
+}
+
+// git runs git with args and returns its output, or an error including what git wrote
+// to stderr.
+func git(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// keep reports whether is was found on a line that was added or modified.
+func (c changedLines) keep(is issue) bool {
+	for _, r := range c[is.position.Filename] {
+		if is.position.Line >= r.first && is.position.Line <= r.last {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filter returns the issues of issues on lines that were added or modified.
+func (c changedLines) filter(issues []issue) []issue {
+	var kept []issue
+	for _, is := range issues {
+		if c.keep(is) {
+			kept = append(kept, is)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParseDiff parses the unified diffs git writes for the edge cases of the format,
+// verifying the lines found added or modified.
+func TestParseDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		diff string
+		want changedLines
+	}{
+		{
+			name: "modified lines",
+			diff: `diff --git a/p.go b/p.go
+--- a/p.go
++++ b/p.go
+@@ -2,3 +2,4 @@ package p
+
+-// F is f
++// F is f.
++// It returns nothing.
+ func F() {}
+`,
+			want: changedLines{"/r/p.go": {{2, 5}}},
+		},
+		{
+			name: "added lines beginning with ++",
+			diff: `--- a/p.go
++++ b/p.go
+@@ -1,2 +1,4 @@
+ package p
++++ b/q.go
++--- a/q.go
+ // end
+@@ -10 +12 @@
+-x
++y
+`,
+			want: changedLines{"/r/p.go": {{1, 4}, {12, 12}}},
+		},
+		{
+			name: "no newline at end of file",
+			diff: `--- a/p.go
++++ b/p.go
+@@ -1,2 +1,2 @@
+ package p
+-// end
+\ No newline at end of file
++// end.
+\ No newline at end of file
+--- a/q.go
++++ b/q.go
+@@ -3 +3 @@
+-a
++b
+`,
+			want: changedLines{"/r/p.go": {{1, 2}}, "/r/q.go": {{3, 3}}},
+		},
+		{
+			name: "added file",
+			diff: `diff --git a/new.go b/new.go
+new file mode 100644
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1,3 @@
++// Package p is p.
++package p
++
+`,
+			want: changedLines{"/r/new.go": {{1, 3}}},
+		},
+		{
+			name: "deleted file",
+			diff: `diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package p
+-
+`,
+			want: changedLines{},
+		},
+		{
+			name: "quoted path with escapes",
+			diff: `--- "a/na\303\257ve \"q\".go"
++++ "b/na\303\257ve \"q\".go"
+@@ -1 +1 @@
+-package p
++package q
+`,
+			want: changedLines{"/r/naïve \"q\".go": {{1, 1}}},
+		},
+		{
+			name: "hunks with count 0",
+			diff: `--- a/p.go
++++ b/p.go
+@@ -3,0 +4,2 @@
++a
++b
+@@ -9,2 +10,0 @@
+-c
+-d
+@@ -20,0 +19 @@
++e
+`,
+			want: changedLines{"/r/p.go": {{4, 5}, {19, 19}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseDiff(strings.NewReader(test.diff), "/r")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parseDiff returned %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+// TestHeaderPath verifies the paths read from the headers of the files of diffs.
+func TestHeaderPath(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+		err    bool
+	}{
+		{"b/p.go", "b/p.go", false},
+		{"b/p.go\t2024-01-02 15:04:05.000000000 +0100", "b/p.go", false},
+		{"/dev/null", "/dev/null", false},
+		{"b/with space.go", "b/with space.go", false},
+		{`"b/na\303\257ve.go"`, "b/naïve.go", false},
+		{`"b/tab\there.go"`, "b/tab\there.go", false},
+		{`"b/quote\"d.go"` + "\t2024-01-02", `b/quote"d.go`, false},
+		{`"b/unterminated.go`, "", true},
+	}
+
+	for _, test := range tests {
+		got, err := headerPath(test.header)
+		if (err != nil) != test.err {
+			t.Errorf("headerPath(%q) returned error %v, want error %t", test.header, err, test.err)
+			continue
+		}
+
+		if got != test.want {
+			t.Errorf("headerPath(%q) = %q, want %q", test.header, got, test.want)
+		}
+	}
+}
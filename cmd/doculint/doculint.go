@@ -1,10 +1,414 @@
+// Package main is the entrypoint for the doculint command, a driver for the doculint
+// analyzer that loads packages, runs the analysis, and prints the findings.
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
 	"github.com/george-e-shaw-iv/doculint/internal/doculint"
-	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/analysis/unitchecker"
+	"golang.org/x/tools/go/packages"
+)
+
+// Exit codes returned by the doculint command.
+const (
+	// exitOK is returned when the analysis ran and found nothing to report.
+	exitOK = 0
+
+	// exitError is returned when packages could not be loaded or analyzed.
+	exitError = 1
+
+	// exitFindings is returned when the analysis reported at least one finding in text
+	// mode, matching the exit code used by the go/analysis checkers.
+	exitFindings = 3
+)
+
+// linter is the linter whose analyzer the command runs, configured through the flags of
+// the analyzer, which are registered alongside those of the command.
+var linter = doculint.New()
+
+// concurrencyUsage is the usage of the -concurrency flag, shared by the subcommands.
+const concurrencyUsage = "maximum number of packages analyzed at once, defaults to the number of CPUs"
+
+// Command line flags specific to the driver, the analyzer's own flags are registered
+// alongside them in main.
+var (
+	// jsonOutput controls whether findings are emitted as JSON on stdout instead of
+	// plain text on stderr.
+	jsonOutput = flag.Bool("json", false, "emit JSON output, same as -format=json")
+
+	// outputFormat is the format findings are emitted in: text on stderr, or one of the
+	// machine readable formats on stdout.
+	outputFormat = flag.String("format", "text", "output format: text, plain for path:line:column: message (rule) lines, json, rdjson for reviewdog, gerrit for Gerrit robot comments, sonarqube for SonarQube external issues, or codeclimate and codeclimate-engine for Code Climate")
+
+	// includeTests controls whether test files are analyzed as well.
+	includeTests = flag.Bool("test", true, "indicates whether test files should be analyzed, too")
+
+	// concurrency is the maximum number of packages analyzed at once, the number of CPUs
+	// usable by the process if zero or negative.
+	concurrency = flag.Int("concurrency", 0, concurrencyUsage)
+
+	// maxFiles is the maximum number of Go files of the packages loaded at once, all of
+	// them being loaded at once if zero or negative.
+	maxFiles = flag.Int("max-files", 0, "maximum number of Go files of the packages loaded and analyzed at once, bounding the syntax trees held in memory on large repositories, all packages being loaded at once if zero")
+
+	// memLimit is the soft memory limit of the runtime.
+	memLimit memoryLimit
+
+	// applyFixes controls whether suggested fixes are applied to the analyzed files.
+	applyFixes = flag.Bool("fix", false, "apply all suggested fixes")
+
+	// printHints controls whether a summary with next steps is printed after a run that
+	// reported issues in text mode.
+	printHints = flag.Bool("hints", false, "print a summary of the issues found with suggested next steps")
+
+	// printSuppressions controls whether a report of the //nolint comments suppressing
+	// the issues of files and packages is printed after the findings in text mode.
+	printSuppressions = flag.Bool("suppressions", false, "print a report of the //nolint comments suppressing issues of files and packages")
+
+	// watchMode controls whether the analysis is rerun for the packages whose files
+	// change until the command is interrupted.
+	watchMode = flag.Bool("watch", false, "reanalyze the packages whose files change, printing their issues and a summary, until interrupted")
+
+	// watchInterval is how long changes are collected in watch mode before the packages
+	// are reanalyzed.
+	watchInterval = flag.Duration("watch-interval", time.Second, "how long changes are collected with -watch before the packages whose files changed are reanalyzed")
+
+	// newFromRev is the git revision relative to which only the issues on added or
+	// modified lines are reported.
+	newFromRev = flag.String("new-from-rev", "", "only report issues on lines added or modified relative to the given git revision, such as main or HEAD~1")
+
+	// diffPath is the path of a unified diff, or - for stdin, whose added or modified
+	// lines are the only ones issues are reported on.
+	diffPath = flag.String("diff", "", "only report issues on lines added or modified by the unified diff in the given file, or - to read it from stdin")
+
+	// changedOnly controls whether only the packages affected by the changes of the
+	// working tree are analyzed.
+	changedOnly = flag.Bool("changed", false, "only analyze the packages with files changed in the git working tree relative to HEAD, or to -new-from-rev, and the packages importing them")
+
+	// useCache controls whether the issues of packages that did not change since a
+	// previous run are taken from the cache rather than found again.
+	useCache = flag.Bool("cache", false, "reuse the issues of the packages whose files, dependencies, configuration, and doculint binary did not change since a previous run, ignored with -suppressions")
+
+	// cacheDir is the directory of the cache used with -cache.
+	cacheDir = flag.String("cache-dir", "", "directory of the cache used with -cache, defaults to the doculint directory of the user's cache directory")
+
+	// staged controls whether the Go files staged in the git index are analyzed, as found
+	// in the index, in place of the packages given as arguments.
+	staged = flag.Bool("staged", false, "analyze the Go files staged in the git index, with their content in the index rather than in the working tree, in place of the arguments, ignoring -changed and -cache")
+
+	// explainRule is the ID or name of a rule whose explanation is printed instead of
+	// running the analysis.
+	explainRule = flag.String("explain", "", "print the description, examples, and configuration of the rule with the given ID, such as DL004, and exit")
 )
 
 func main() {
-	singlechecker.Main(&doculint.Analyzer)
+	log.SetFlags(0)
+	log.SetPrefix("doculint: ")
+
+	if isVetInvocation(os.Args[1:]) {
+		// Defer to the unit checker protocol used by go vet -vettool, which defines
+		// its own flags.
+		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+		unitchecker.Main(linter.Analyzer)
+	}
+
+	flag.Var(&memLimit, "memory-limit", memoryLimitUsage)
+	registerAnalyzerFlags(flag.CommandLine)
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "serve":
+			os.Exit(serve(os.Args[2:]))
+		case "lsp":
+			os.Exit(lsp(os.Args[2:]))
+		case "hook":
+			os.Exit(hook(os.Args[2:]))
+		case "github":
+			os.Exit(github(os.Args[2:]))
+		case "setup":
+			os.Exit(setup(os.Args[2:]))
+		}
+	}
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "%s\n\nUsage: doculint [-flag] [package | file.go ...]\n       doculint -explain DLxxx\n       doculint serve [-flag]\n       doculint lsp [-flag]\n       doculint hook install|config [-flag]\n       doculint github -repo owner/name -pr number [-flag] [package | file.go ...]\n       doculint setup [-flag] vscode|goland|vim\n\nFlags:\n", linter.Analyzer.Doc)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *explainRule != "" {
+		os.Exit(explain(os.Stdout, *explainRule))
+	}
+
+	if *staged {
+		if flag.NArg() > 0 || *watchMode || *applyFixes {
+			log.Print("-staged takes no arguments and cannot be used with -watch or -fix")
+			os.Exit(exitError)
+		}
+
+		os.Exit(run(nil))
+	}
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(exitError)
+	}
+
+	if *watchMode {
+		os.Exit(watch(os.Stderr, flag.Args(), *watchInterval))
+	}
+
+	os.Exit(run(flag.Args()))
+}
+
+// isVetInvocation reports whether the command was invoked by go vet -vettool, which
+// queries the version and flags of the tool before running it on a configuration file
+// describing each package.
+func isVetInvocation(args []string) bool {
+	for _, arg := range args {
+		if arg == "-V=full" || arg == "-flags" {
+			return true
+		}
+	}
+
+	return len(args) > 0 && strings.HasSuffix(args[len(args)-1], ".cfg")
+}
+
+// registerAnalyzerFlags registers the flags of the doculint analyzer on fs, sharing
+// their values with the analyzer.
+func registerAnalyzerFlags(fs *flag.FlagSet) {
+	linter.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+}
+
+// run loads the packages matching args, analyzes them, and prints the findings,
+// returning the exit code the command should terminate with.
+func run(args []string) int {
+	code := exitOK
+
+	format := *outputFormat
+	if *jsonOutput {
+		format = "json"
+	}
+	if _, ok := formats[format]; !ok && format != "text" {
+		log.Printf("unknown format \"%s\", expected one of text, plain, json, rdjson, gerrit, sonarqube, codeclimate, or codeclimate-engine", format)
+		return exitError
+	}
+
+	var (
+		issues       []issue
+		suppressions []suppression
+		err          error
+	)
+
+	if *staged {
+		args, overlay, err = stagedFiles()
+		if err != nil {
+			log.Print(err)
+			return exitError
+		}
+	} else if *changedOnly {
+		rev := *newFromRev
+		if rev == "" {
+			rev = "HEAD"
+		}
+
+		args, err = changedPackages(args, rev)
+		if err != nil {
+			log.Print(err)
+			return exitError
+		}
+	}
+
+	switch {
+	case len(args) == 0:
+		// No package is affected by the changes of the working tree, or no Go file is
+		// staged.
+	case *useCache && !*printSuppressions && !*staged:
+		var c *resultCache
+		c, err = openCache(*cacheDir)
+		if err != nil {
+			log.Print(err)
+			return exitError
+		}
+
+		issues, code, err = c.analyze(args)
+		if err != nil {
+			log.Print(err)
+			return exitError
+		}
+	default:
+		issues, suppressions, code, err = analyzeArgs(args, *printSuppressions)
+		if err != nil {
+			log.Print(err)
+			return exitError
+		}
+	}
+
+	if *newFromRev != "" || *diffPath != "" {
+		var changed changedLines
+		if *newFromRev != "" {
+			changed, err = gitChangedLines(*newFromRev)
+		} else {
+			changed, err = readDiff(*diffPath)
+		}
+		if err != nil {
+			log.Print(err)
+			return exitError
+		}
+
+		issues = changed.filter(issues)
+	}
+
+	if *applyFixes {
+		remaining, err := fix(issues)
+		if err != nil {
+			log.Print(err)
+			code = exitError
+		}
+		issues = remaining
+	}
+
+	if write, ok := formats[format]; ok {
+		if err := write(os.Stdout, issues); err != nil {
+			log.Print(err)
+			return exitError
+		}
+
+		// The plain format is read by people and editors, as the text format is, while
+		// the others are consumed by tools that report the findings themselves.
+		if format == "plain" && len(issues) > 0 && code == exitOK {
+			code = exitFindings
+		}
+
+		return code
+	}
+
+	writeText(os.Stderr, issues)
+	if *printHints {
+		writeHints(os.Stderr, issues)
+	}
+	if *printSuppressions {
+		writeSuppressions(os.Stderr, suppressions)
+	}
+
+	if len(issues) > 0 && code == exitOK {
+		code = exitFindings
+	}
+
+	return code
+}
+
+// load loads the packages matching args, which are package patterns or paths to Go
+// files, relative to dir or the working directory if dir is empty. The returned filter
+// restricts findings to the files given in args.
+func load(dir string, args []string) ([]*packages.Package, fileFilter, error) {
+	patterns, files, err := resolveArgs(dir, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:    packages.LoadSyntax | packages.NeedModule,
+		Dir:     dir,
+		Tests:   *includeTests,
+		Overlay: overlay,
+	}, patterns...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(pkgs) == 0 {
+		return nil, nil, fmt.Errorf("%v matched no packages", args)
+	}
+
+	return pkgs, files, nil
+}
+
+// analyzeArgs returns the issues of the packages matching args that are kept by the
+// files given in args, along with their suppressions if withSuppressions is set,
+// loading and analyzing the packages in batches of up to -max-files Go files. The
+// returned code is exitError if packages had errors, exitOK otherwise, and the returned
+// error is only set if packages could not be listed or loaded.
+func analyzeArgs(args []string, withSuppressions bool) ([]issue, []suppression, int, error) {
+	patterns, files, err := resolveArgs("", args)
+	if err != nil {
+		return nil, nil, exitError, err
+	}
+
+	var issues []issue
+	var suppressions []suppression
+	var errs []error
+	failed, err := analyzeBatches(patterns, func(graph *checker.Graph) {
+		found, err := collect(graph, files)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		issues = append(issues, found...)
+
+		if withSuppressions {
+			suppressions = append(suppressions, collectSuppressions(graph, files)...)
+		}
+	})
+	if err != nil {
+		return nil, nil, exitError, err
+	}
+
+	code := exitOK
+	if failed {
+		code = exitError
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		log.Print(err)
+		code = exitError
+	}
+
+	return mergeIssues(issues), suppressions, code, nil
+}
+
+// analyze runs the doculint analyzer on pkgs and returns the issues kept by files.
+func analyze(pkgs []*packages.Package, files fileFilter) ([]issue, error) {
+	graph, err := analyzePackages(pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return collect(graph, files)
+}
+
+// analyzePackages runs the doculint analyzer on pkgs, and on their dependencies for the
+// facts they export, analyzing up to -concurrency packages at once on as many
+// goroutines. Packages are analyzed as soon as their dependencies are, so that
+// independent packages keep every worker busy on many-core machines.
+func analyzePackages(pkgs []*packages.Package) (*checker.Graph, error) {
+	n := *concurrency
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+
+	// The checker starts a goroutine per package, the analysis of which waits for a
+	// worker once those of its dependencies are done, so that no worker is held while
+	// waiting on another.
+	workers := make(chan struct{}, n)
+	analyzer := *linter.Analyzer
+	analyzer.Run = func(pass *analysis.Pass) (interface{}, error) {
+		workers <- struct{}{}
+		defer func() { <-workers }()
+
+		return linter.Analyzer.Run(pass)
+	}
+
+	return checker.Analyze([]*analysis.Analyzer{&analyzer}, pkgs, &checker.Options{Sequential: n == 1})
 }
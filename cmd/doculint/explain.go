@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/george-e-shaw-iv/doculint/docs"
+)
+
+// explain writes the explanation of the rule with the given ID or name to w, along
+// with the URL of its documentation, and returns the exit code the command should
+// terminate with.
+func explain(w io.Writer, id string) int {
+	rule, ok := linter.LookupRule(strings.ToUpper(strings.TrimSpace(id)))
+	if !ok {
+		for _, r := range linter.Rules() {
+			if strings.EqualFold(r.Name, strings.TrimSpace(id)) {
+				rule, ok = r, true
+				break
+			}
+		}
+	}
+
+	text, documented := docs.Explain(rule.ID)
+	if !ok || !documented {
+		log.Printf("unknown rule \"%s\", expected an ID such as DL004 or a name such as function-comment", id)
+		return exitError
+	}
+
+	fmt.Fprintf(w, "%s %s\n\n%s\n\nSee %s\n", rule.ID, rule.Name, text, rule.URL())
+	return exitOK
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// fileFilter is the set of absolute paths of the Go files given directly as command
+// line arguments. Findings in the packages containing those files are restricted to
+// the files themselves.
+type fileFilter map[string]bool
+
+// resolveArgs splits the command line arguments into package patterns and the files
+// given directly, with relative file paths resolved against dir or the working
+// directory if dir is empty. Each file is replaced by a query for the package
+// containing it, so that the package is analyzed as a whole rather than as a package
+// made up of only the given files.
+func resolveArgs(dir string, args []string) ([]string, fileFilter, error) {
+	patterns := make([]string, 0, len(args))
+	files := make(fileFilter)
+
+	for _, arg := range args {
+		if !strings.HasSuffix(arg, ".go") {
+			patterns = append(patterns, arg)
+			continue
+		}
+
+		path := arg
+		if dir != "" && !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			patterns = append(patterns, arg)
+			continue
+		}
+
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		files[abs] = true
+		patterns = append(patterns, "file="+abs)
+	}
+
+	return patterns, files, nil
+}
+
+// filter returns the issues reported for pkg that are kept by ff.
+func (ff fileFilter) filter(pkg *packages.Package, issues []issue) []issue {
+	kept := issues[:0]
+	for _, is := range issues {
+		// Findings for the package as a whole are positioned in whichever file holds
+		// the package clause.
+		if rule, ok := linter.LookupRule(is.Rule); (ok && rule.Package) || ff.keep(pkg, is.position) {
+			kept = append(kept, is)
+		}
+	}
+
+	return kept
+}
+
+// keep reports whether a finding at position, reported for pkg, should be kept. Only
+// findings in packages containing one of the files in the filter are filtered, and
+// findings without a file are always kept. Findings for the package as a whole are kept
+// by the caller, since they are positioned in whichever file holds the package clause.
+func (ff fileFilter) keep(pkg *packages.Package, position token.Position) bool {
+	if len(ff) == 0 || position.Filename == "" || ff[position.Filename] {
+		return true
+	}
+
+	for _, file := range pkg.CompiledGoFiles {
+		if ff[file] {
+			return false
+		}
+	}
+
+	return true
+}
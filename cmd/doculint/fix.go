@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"slices"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// edit is a single text edit of a suggested fix, resolved to byte offsets within a
+// file.
+type edit struct {
+	// file is the name of the file the edit applies to.
+	file string
+
+	// start and end are the byte offsets of the text replaced by the edit.
+	start, end int
+
+	// text is the replacement text.
+	text []byte
+}
+
+// resolveEdits resolves the text edits of the first suggested fix of diag to byte
+// offsets. Diagnostics may only carry fixes that do not overlap, so only the first is
+// considered.
+func resolveEdits(fset *token.FileSet, diag analysis.Diagnostic) []edit {
+	if len(diag.SuggestedFixes) == 0 {
+		return nil
+	}
+
+	var edits []edit
+	for _, te := range diag.SuggestedFixes[0].TextEdits {
+		tf := fset.File(te.Pos)
+		if tf == nil {
+			return nil
+		}
+
+		end := te.End
+		if !end.IsValid() {
+			end = te.Pos
+		}
+
+		edits = append(edits, edit{
+			file:  tf.Name(),
+			start: tf.Offset(te.Pos),
+			end:   tf.Offset(end),
+			text:  te.NewText,
+		})
+	}
+
+	return edits
+}
+
+// fix applies the suggested fixes of issues to the files on disk and returns the issues
+// left, with their positions moved to where the applied edits put them. Each fix is
+// applied or skipped as a whole: fixes overlapping a fix applied before them, in the
+// order of issues, or out of the bounds of their files are skipped, and their issues are
+// returned without the fix, which no longer applies. Fixes that duplicate an applied
+// fix, which happens for files that belong to both a package and its test variant, are
+// applied once. The issues that carried no fix are returned too, except those whose
+// range the applied edits changed, which no longer describe the files.
+func fix(issues []issue) ([]issue, error) {
+	var errs []error
+	sources := make(map[string][]byte)
+	source := func(file string) ([]byte, bool) {
+		if src, ok := sources[file]; ok {
+			return src, src != nil
+		}
+
+		src, err := os.ReadFile(file)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		sources[file] = src
+		return src, err == nil
+	}
+
+	byFile := make(map[string][]edit)
+	skipped := make([]bool, len(issues))
+	for i := range issues {
+		if len(issues[i].edits) == 0 || isApplied(issues[i].edits, byFile) {
+			continue
+		}
+
+		if !canApply(issues[i].edits, byFile, source) {
+			skipped[i] = true
+			continue
+		}
+
+		for _, e := range issues[i].edits {
+			byFile[e.file] = append(byFile[e.file], e)
+		}
+	}
+
+	files := make([]string, 0, len(byFile))
+	for file := range byFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	fixed := make(map[string][]byte)
+	for _, file := range files {
+		src, err := applyEdits(file, sources[file], byFile[file])
+		if err != nil {
+			errs = append(errs, err)
+			delete(byFile, file)
+			continue
+		}
+		fixed[file] = src
+	}
+
+	var remaining []issue
+	for i, is := range issues {
+		switch {
+		case skipped[i]:
+			is.edits = nil
+		case len(is.edits) > 0 || changesRange(byFile[is.position.Filename], is):
+			continue
+		}
+
+		remaining = append(remaining, moveIssue(is, byFile, fixed))
+	}
+
+	return remaining, errors.Join(errs...)
+}
+
+// isApplied reports whether edits are among the edits to apply, byFile.
+func isApplied(edits []edit, byFile map[string][]edit) bool {
+	for _, e := range edits {
+		if !slices.ContainsFunc(byFile[e.file], e.equal) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// canApply reports whether edits are within the bounds of the files they apply to, read
+// with source, and overlap neither one another nor the edits to apply, byFile.
+func canApply(edits []edit, byFile map[string][]edit, source func(string) ([]byte, bool)) bool {
+	for i, e := range edits {
+		src, ok := source(e.file)
+		if !ok || e.start < 0 || e.start > e.end || e.end > len(src) {
+			return false
+		}
+
+		for _, other := range slices.Concat(edits[:i], byFile[e.file]) {
+			if other.file == e.file && e.conflicts(other) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// equal reports whether e and other make the same change.
+func (e edit) equal(other edit) bool {
+	return e.file == other.file && e.start == other.start && e.end == other.end && bytes.Equal(e.text, other.text)
+}
+
+// conflicts reports whether e and other, which apply to the same file, cannot both be
+// applied: they replace overlapping text, or insert text at the same offset, whose
+// order would be undefined, or one inserts text inside the text the other replaces.
+func (e edit) conflicts(other edit) bool {
+	if e.start == e.end || other.start == other.end {
+		return e.start == other.start || (e.start > other.start && e.start < other.end) || (other.start > e.start && other.start < e.end)
+	}
+
+	return e.start < other.end && other.start < e.end
+}
+
+// changesRange reports whether one of edits changes the text in the range of is, or the
+// character at its position if it has no range. Insertions only change ranges they are
+// inside of.
+func changesRange(edits []edit, is issue) bool {
+	start, end := is.position.Offset, is.end.Offset
+	if !is.end.IsValid() || end <= start {
+		end = start + 1
+	}
+
+	for _, e := range edits {
+		if e.start == e.end {
+			if start < e.start && e.start < end {
+				return true
+			}
+		} else if start < e.end && e.start < end {
+			return true
+		}
+	}
+
+	return false
+}
+
+// moveIssue returns is with its positions, and those of its related locations, moved to
+// where edits, the edits applied to each file, put them in the fixed content of the
+// files. Positions within the text replaced by an edit are moved to its start, and
+// text inserted at the start of a range is left out of it, as is text inserted at its
+// end.
+func moveIssue(is issue, edits map[string][]edit, fixed map[string][]byte) issue {
+	move := func(p token.Position, end bool) token.Position {
+		src, ok := fixed[p.Filename]
+		if !ok || !p.IsValid() {
+			return p
+		}
+
+		offset := p.Offset
+		for _, e := range edits[p.Filename] {
+			switch {
+			case e.start == e.end && e.start == p.Offset:
+				if !end {
+					offset += len(e.text)
+				}
+			case e.end <= p.Offset:
+				offset += len(e.text) - (e.end - e.start)
+			case e.start < p.Offset:
+				offset -= p.Offset - e.start
+			}
+		}
+
+		p.Offset = min(max(offset, 0), len(src))
+		p.Line = 1 + bytes.Count(src[:p.Offset], []byte("\n"))
+		p.Column = p.Offset - bytes.LastIndexByte(src[:p.Offset], '\n')
+		return p
+	}
+
+	is.position = move(is.position, false)
+	is.Posn = is.position.String()
+	if is.end.IsValid() {
+		is.end = move(is.end, true)
+		is.End = endPosn(is.end)
+	}
+
+	if len(is.Related) > 0 {
+		rel := make([]related, len(is.Related))
+		for i, r := range is.Related {
+			r.position = move(r.position, false)
+			r.Posn = r.position.String()
+			if r.end.IsValid() {
+				r.end = move(r.end, true)
+			}
+			rel[i] = r
+		}
+		is.Related = rel
+	}
+
+	return is
+}
+
+// applyEdits applies edits, which neither overlap nor exceed src, to src, the content of
+// the given file, rewriting the file, and returns its new content.
+func applyEdits(file string, src []byte, edits []edit) ([]byte, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+
+	edits = slices.Clone(edits)
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].start < edits[j].start
+	})
+
+	var out bytes.Buffer
+	offset := 0
+	for _, e := range edits {
+		out.Write(src[offset:e.start])
+		out.Write(e.text)
+		offset = e.end
+	}
+	out.Write(src[offset:])
+
+	if err := os.WriteFile(file, out.Bytes(), info.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("apply fixes to %s: %w", file, err)
+	}
+
+	return out.Bytes(), nil
+}
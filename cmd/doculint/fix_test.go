@@ -0,0 +1,63 @@
+package main
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestFix applies fixes that overlap one another, verifying that each fix is applied or
+// skipped as a whole, and that the issues left are reported at their position in the
+// fixed file.
+func TestFix(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "p.go")
+	src := "package p\n\nfunc A() {}\n\nfunc B() {}\n"
+	if err := os.WriteFile(file, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	at := func(offset int) token.Position {
+		return token.Position{Filename: file, Offset: offset, Line: 1, Column: 1}
+	}
+	insert := func(offset int, text string) edit {
+		return edit{file: file, start: offset, end: offset, text: []byte(text)}
+	}
+
+	a, b := 11, 24
+	issues := []issue{
+		// Applied along with its second edit.
+		{Rule: "DL001", position: at(a), edits: []edit{insert(a, "// A is a.\n"), insert(b, "// B is b.\n")}},
+		// Applied once, duplicating the first fix.
+		{Rule: "DL002", position: at(a), edits: []edit{insert(a, "// A is a.\n"), insert(b, "// B is b.\n")}},
+		// Skipped as a whole, since its second edit conflicts with the first fix.
+		{Rule: "DL003", position: at(a + 5), edits: []edit{{file: file, start: a + 5, end: a + 6, text: []byte("a")}, insert(b, "// B.\n")}},
+		// Moved past the comments inserted before it.
+		{Rule: "DL004", position: at(b + 5)},
+	}
+
+	remaining, err := fix(issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "package p\n\n// A is a.\nfunc A() {}\n\n// B is b.\nfunc B() {}\n"; string(got) != want {
+		t.Errorf("fixed file is\n%s\nwant\n%s", got, want)
+	}
+
+	var posns []string
+	for _, is := range remaining {
+		if is.edits != nil {
+			t.Errorf("issue %s kept the fix that was skipped", is.Rule)
+		}
+		posns = append(posns, is.Rule+" "+filepath.Base(is.Posn))
+	}
+	if want := []string{"DL003 p.go:4:6", "DL004 p.go:7:6"}; !slices.Equal(posns, want) {
+		t.Errorf("remaining issues are %v, want %v", posns, want)
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+)
+
+// gerritReview is the part of a Gerrit ReviewInput holding robot comments, see
+// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#review-input.
+type gerritReview struct {
+	// RobotComments are the robot comments of the review, keyed by the path of the file
+	// they are attached to.
+	RobotComments map[string][]gerritRobotComment `json:"robot_comments"`
+}
+
+// gerritRobotComment is an issue in Gerrit RobotCommentInput form.
+type gerritRobotComment struct {
+	// RobotID is always "doculint".
+	RobotID string `json:"robot_id"`
+
+	// RobotRunID identifies the run that produced the comment.
+	RobotRunID string `json:"robot_run_id"`
+
+	// URL is the URL of the documentation of the rule that reported the issue.
+	URL string `json:"url,omitempty"`
+
+	// Properties hold the rule and confidence of the issue.
+	Properties map[string]string `json:"properties,omitempty"`
+
+	// Line is the line the comment is attached to, the last line of Range if set.
+	Line int `json:"line"`
+
+	// Range is the range of the issue, if the rule reported one.
+	Range *gerritRange `json:"range,omitempty"`
+
+	// Message is the human readable description of the issue.
+	Message string `json:"message"`
+
+	// FixSuggestions hold the suggested fix of the issue, if any.
+	FixSuggestions []gerritFixSuggestion `json:"fix_suggestions,omitempty"`
+}
+
+// gerritRange is a range of a file, with one-based lines and zero-based character
+// offsets, end exclusive.
+type gerritRange struct {
+	// StartLine and StartCharacter are the start of the range.
+	StartLine      int `json:"start_line"`
+	StartCharacter int `json:"start_character"`
+
+	// EndLine and EndCharacter are the end of the range.
+	EndLine      int `json:"end_line"`
+	EndCharacter int `json:"end_character"`
+}
+
+// gerritFixSuggestion is a suggested fix of a robot comment.
+type gerritFixSuggestion struct {
+	// Description describes the fix.
+	Description string `json:"description"`
+
+	// Replacements are the edits of the fix.
+	Replacements []gerritReplacement `json:"replacements"`
+}
+
+// gerritReplacement is an edit of a suggested fix.
+type gerritReplacement struct {
+	// Path is the path of the file edited.
+	Path string `json:"path"`
+
+	// Range is the range replaced.
+	Range gerritRange `json:"range"`
+
+	// Replacement is the replacement text.
+	Replacement string `json:"replacement"`
+}
+
+// writeGerrit writes issues to w as the robot comments of a Gerrit ReviewInput, which CI
+// can post to a change as is. Paths are relative to the working directory, which should
+// be the root of the repository. The run is identified by $BUILD_ID if set, or by the
+// current time otherwise. Issues without a position are skipped, since Gerrit attaches
+// every comment to a file.
+func writeGerrit(w io.Writer, issues []issue) error {
+	run := os.Getenv("BUILD_ID")
+	if run == "" {
+		run = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	review := gerritReview{RobotComments: make(map[string][]gerritRobotComment)}
+	for i := range issues {
+		is := &issues[i]
+		if !is.position.IsValid() {
+			continue
+		}
+
+		comment := gerritRobotComment{
+			RobotID:    "doculint",
+			RobotRunID: run,
+			URL:        is.URL,
+			Properties: map[string]string{"rule": is.Rule, "confidence": is.Confidence},
+			Line:       is.position.Line,
+			Message:    is.Message,
+		}
+
+		// Gerrit counts characters rather than bytes, which takes the content of the
+		// file to convert columns.
+		content, err := os.ReadFile(is.position.Filename)
+		if err == nil && is.end.IsValid() && is.end.Filename == is.position.Filename {
+			comment.Range = gerritRangeOf(content, is.position.Offset, is.end.Offset)
+			comment.Line = comment.Range.EndLine
+		}
+
+		var replacements []gerritReplacement
+		for _, e := range is.edits {
+			content, err := os.ReadFile(e.file)
+			if err != nil {
+				continue
+			}
+
+			replacements = append(replacements, gerritReplacement{
+				Path:        relativePath(e.file),
+				Range:       *gerritRangeOf(content, e.start, e.end),
+				Replacement: string(e.text),
+			})
+		}
+		if len(replacements) > 0 {
+			comment.FixSuggestions = []gerritFixSuggestion{{
+				Description:  "Apply the fix of " + is.Rule,
+				Replacements: replacements,
+			}}
+		}
+
+		path := relativePath(is.position.Filename)
+		review.RobotComments[path] = append(review.RobotComments[path], comment)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(review)
+}
+
+// gerritRangeOf converts the byte offsets start and end of content to a Gerrit range.
+func gerritRangeOf(content []byte, start, end int) *gerritRange {
+	r := &gerritRange{}
+	r.StartLine, r.StartCharacter = characterPosition(content, start)
+	r.EndLine, r.EndCharacter = characterPosition(content, end)
+	return r
+}
+
+// characterPosition converts the byte offset of content to a one-based line and a
+// zero-based character offset in that line, as used by Gerrit and SonarQube.
+func characterPosition(content []byte, offset int) (line, character int) {
+	offset = min(max(offset, 0), len(content))
+
+	line = 1
+	for _, r := range string(content[:offset]) {
+		if r == '\n' {
+			line++
+			character = 0
+		} else {
+			character++
+		}
+	}
+
+	return line, character
+}
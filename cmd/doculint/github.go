@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// githubReviewSize is the number of comments posted per review, keeping each request
+// well within the limits of the GitHub API.
+const githubReviewSize = 50
+
+// githubMarker prefixes the hidden marker ending the body of every comment posted by
+// doculint, which identifies the rule and is used to recognize the comments of previous
+// runs.
+const githubMarker = "<!-- doculint:"
+
+// githubClient calls the REST API of GitHub on behalf of a token.
+type githubClient struct {
+	// api is the base URL of the API, such as https://api.github.com.
+	api string
+
+	// token authenticates the requests.
+	token string
+
+	// http is the client used to send requests.
+	http *http.Client
+}
+
+// githubFile is a file changed by a pull request.
+type githubFile struct {
+	// Filename is the path of the file, relative to the root of the repository.
+	Filename string `json:"filename"`
+
+	// Patch is the unified diff of the file, without file headers. It is empty for
+	// binary and very large files.
+	Patch string `json:"patch"`
+}
+
+// githubComment is an inline review comment.
+type githubComment struct {
+	// Path is the path of the file commented on, relative to the root of the
+	// repository.
+	Path string `json:"path"`
+
+	// Line is the line of the new side of the diff commented on.
+	Line int `json:"line"`
+
+	// Side is always RIGHT, the new side of the diff.
+	Side string `json:"side,omitempty"`
+
+	// Body is the text of the comment.
+	Body string `json:"body"`
+}
+
+// github posts the issues found in the packages matching the arguments of args as
+// inline review comments on a GitHub pull request. Only the issues on lines of the diff
+// of the pull request are posted, since GitHub rejects comments elsewhere, and issues
+// already commented on by a previous run are skipped. It returns the exit code the
+// command should terminate with, which reports findings as the default mode does.
+func github(args []string) int {
+	fs := flag.NewFlagSet("github", flag.ExitOnError)
+	repo := fs.String("repo", os.Getenv("GITHUB_REPOSITORY"), "repository of the pull request, as owner/name, defaulting to $GITHUB_REPOSITORY")
+	pr := fs.Int("pr", 0, "number of the pull request")
+	api := fs.String("api", "https://api.github.com", "base URL of the GitHub API, for GitHub Enterprise Server")
+	fs.BoolVar(includeTests, "test", true, "indicates whether test files should be analyzed, too")
+	fs.IntVar(concurrency, "concurrency", 0, concurrencyUsage)
+	fs.IntVar(maxFiles, "max-files", 0, "maximum number of Go files of the packages loaded and analyzed at once, all packages being loaded at once if zero")
+	fs.Var(&memLimit, "memory-limit", memoryLimitUsage)
+	registerAnalyzerFlags(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: doculint github -repo owner/name -pr number [-flag] [package | file.go ...]\n\nPosts the issues found as review comments on a pull request, authenticating with $GITHUB_TOKEN.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	token := os.Getenv("GITHUB_TOKEN")
+	if *repo == "" || *pr <= 0 || token == "" || fs.NArg() == 0 {
+		fs.Usage()
+		return exitError
+	}
+
+	issues, _, code, err := analyzeArgs(fs.Args(), false)
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+
+	root, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+
+	c := &githubClient{api: strings.TrimSuffix(*api, "/"), token: token, http: &http.Client{Timeout: time.Minute}}
+	posted, err := c.review(*repo, *pr, strings.TrimSpace(root), issues)
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+
+	log.Printf("posted %d review comments on %s#%d", posted, *repo, *pr)
+	if code != exitOK {
+		// The errors of the packages were printed, their issues being incomplete.
+		return code
+	}
+	if len(issues) > 0 {
+		return exitFindings
+	}
+
+	return exitOK
+}
+
+// review posts the issues on lines of the diff of the pull request number pr of repo,
+// whose local checkout is rooted at root, that were not commented on before, batched in
+// reviews of githubReviewSize comments. It returns the number of comments posted.
+func (c *githubClient) review(repo string, pr int, root string, issues []issue) (int, error) {
+	var pull struct {
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	}
+	if err := c.do(http.MethodGet, fmt.Sprintf("/repos/%s/pulls/%d", repo, pr), nil, &pull); err != nil {
+		return 0, err
+	}
+
+	var changed []githubFile
+	if err := c.list(fmt.Sprintf("/repos/%s/pulls/%d/files", repo, pr), &changed); err != nil {
+		return 0, err
+	}
+
+	var previous []githubComment
+	if err := c.list(fmt.Sprintf("/repos/%s/pulls/%d/comments", repo, pr), &previous); err != nil {
+		return 0, err
+	}
+
+	// The patches of the pull request hold the lines comments can be attached to.
+	diff := make(changedLines)
+	for _, f := range changed {
+		if f.Patch == "" {
+			continue
+		}
+
+		lines, err := parseDiff(strings.NewReader("+++ b/"+f.Filename+"\n"+f.Patch), root)
+		if err != nil {
+			return 0, err
+		}
+		for path, ranges := range lines {
+			diff[path] = append(diff[path], ranges...)
+		}
+	}
+
+	seen := make(map[githubComment]bool)
+	for _, p := range previous {
+		seen[githubComment{Path: p.Path, Line: p.Line, Body: p.Body}] = true
+	}
+
+	var comments []githubComment
+	for _, is := range diff.filter(issues) {
+		rel, err := filepath.Rel(root, is.position.Filename)
+		if err != nil {
+			continue
+		}
+
+		comment := githubComment{
+			Path: filepath.ToSlash(rel),
+			Line: is.position.Line,
+			Body: githubBody(is),
+		}
+		if seen[comment] {
+			continue
+		}
+		seen[comment] = true
+
+		comment.Side = "RIGHT"
+		comments = append(comments, comment)
+	}
+
+	for start := 0; start < len(comments); start += githubReviewSize {
+		batch := comments[start:min(start+githubReviewSize, len(comments))]
+
+		body := map[string]interface{}{
+			"commit_id": pull.Head.SHA,
+			"event":     "COMMENT",
+			"body":      fmt.Sprintf("doculint found %d documentation issues in this pull request.", len(batch)),
+			"comments":  batch,
+		}
+		if err := c.do(http.MethodPost, fmt.Sprintf("/repos/%s/pulls/%d/reviews", repo, pr), body, nil); err != nil {
+			return start, err
+		}
+	}
+
+	return len(comments), nil
+}
+
+// githubBody returns the body of the review comment posted for is, ending with a hidden
+// marker naming its rule.
+func githubBody(is issue) string {
+	body := fmt.Sprintf("**%s** %s", is.Rule, is.Message)
+	if is.URL != "" {
+		body += fmt.Sprintf(" ([%s](%s))", is.Rule, is.URL)
+	}
+
+	return fmt.Sprintf("%s\n\n%s%s -->", body, githubMarker, is.Rule)
+}
+
+// list gets every page of the list at path, appending the items to the slice pointed
+// to by v.
+func (c *githubClient) list(path string, v interface{}) error {
+	all := make([]json.RawMessage, 0)
+	for page := 1; ; page++ {
+		var items []json.RawMessage
+		if err := c.do(http.MethodGet, path+"?per_page=100&page="+strconv.Itoa(page), nil, &items); err != nil {
+			return err
+		}
+
+		all = append(all, items...)
+		if len(items) < 100 {
+			break
+		}
+	}
+
+	data, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// do sends a request with the JSON encoding of body, if not nil, to path and decodes the
+// JSON response into out, if not nil.
+func (c *githubClient) do(method, path string, body, out interface{}) error {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.api+path, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/george-e-shaw-iv/doculint/internal/doculint"
+)
+
+// ruleHints maps rule IDs to a description of what needs to be done about the issues
+// reported by the rule.
+var ruleHints = map[string]string{
+	doculint.RulePackageName.ID:             "Package names need to be changed to be lowercase without - or _",
+	doculint.RulePackageFile.ID:             "Packages need a file named after them to hold the package comment",
+	doculint.RulePackageComment.ID:          "Packages need a comment beginning with \"Package <name>\"",
+	doculint.RuleFunctionComment.ID:         "Functions need comments beginning with their name",
+	doculint.RuleConstantBlockComment.ID:    "Constant blocks need comments",
+	doculint.RuleConstantComment.ID:         "Constants need comments beginning with their name",
+	doculint.RuleTypeBlockComment.ID:        "Type blocks need comments",
+	doculint.RuleTypeComment.ID:             "Types need comments beginning with their name",
+	doculint.RuleConditionalLiteral.ID:      "Literals in conditionals should be replaced with named constants",
+	doculint.RuleMethodReceiver.ID:          "Method comments should mention their receiver type, or drop -receiver-mention",
+	doculint.RuleCommentPeriod.ID:           "Comments need to end with a period",
+	doculint.RuleProcessExit.ID:             "Calls terminating the process should return errors instead, or drop -exit-calls",
+	doculint.RuleExitComment.ID:             "Functions need to document that they terminate the process",
+	doculint.RuleCommentSentence.ID:         "Comments need to be complete sentences beginning with a capital letter",
+	doculint.RuleDeprecated.ID:              "Deprecation notices need to be paragraphs beginning with \"Deprecated: \"",
+	doculint.RuleDocLink.ID:                 "Doc links need to refer to declared identifiers",
+	doculint.RuleLineLength.ID:              "Comment lines need to be wrapped, run doculint with -rewrap -fix to rewrap them",
+	doculint.RuleCommentMarker.ID:           "Markers such as TODO need to be moved out of the comments of exported declarations",
+	doculint.RuleReadme.ID:                  "READMEs need to be updated to refer to existing, documented identifiers",
+	doculint.RulePackageCommentLength.ID:    "Package comments need to describe what the package provides in more detail",
+	doculint.RuleDuplicatePackageComment.ID: "Package comments need to be merged into a single file",
+	doculint.RuleBlockGrouping.ID:           "Large blocks need to be split into groups separated by blank lines, each introduced by a comment",
+	doculint.RuleExample.ID:                 "Exported functions and types need Example functions in the tests of their package",
+	doculint.RuleBannedPhrase.ID:            "Comments need to describe what declarations do, as in \"Foo does X\", without phrases such as \"this function\"",
+	doculint.RuleErrorSentinel.ID:           "Error sentinels need comments of the form \"ErrFoo is returned when ...\"",
+	doculint.RuleIotaEnum.ID:                "Enum block comments need to describe the enum and mention its type",
+	doculint.RuleFunctionVerb.ID:            "Function comments need to continue with a verb, as in \"Foo returns\", or drop -verbs",
+	doculint.RuleSpelling.ID:                "Misspelled words need to be corrected, or added to the -dictionary file",
+	doculint.RuleLineComment.ID:             "Doc comments need to be line comments, run doculint with -fix to convert them",
+	doculint.RuleDetachedComment.ID:         "Doc comments need to directly precede their declaration, run doculint with -fix to remove the blank lines",
+	doculint.RuleParamReference.ID:          "Function comments need to be updated to refer to the current parameters and results, or drop -params",
+	doculint.RulePanicComment.ID:            "Functions need to document that they panic, and when",
+	doculint.RuleTypeParamComment.ID:        "Comments of generic declarations need to describe each type parameter, or drop -type-params",
+	doculint.RuleEmbeddedComment.ID:         "Embedded fields need comments explaining the behavior they promote, or drop -embedded-docs",
+	doculint.RuleTypeAlias.ID:               "Comments of type aliases need to mention the aliased type",
+	doculint.RuleCgoExport.ID:               "Functions exported to C need a comment above their //export directive",
+	doculint.RuleGenerateComment.ID:         "go:generate directives need a comment explaining what they generate and how to regenerate it",
+	doculint.RuleBuildConstraint.ID:         "Files with build constraints need a comment explaining why the constraint exists",
+	doculint.RuleStutter.ID:                 "Rename identifiers repeating the package name, which callers already write as a qualifier, e.g. doculint.Analyzer rather than doculint.DoculintAnalyzer",
+	doculint.RulePackageNameQuality.ID:      "Name packages after what they provide, with a short, specific name rather than util or common",
+	doculint.RulePackageNamePlural.ID:       "Use singular package names, e.g. model rather than models",
+	doculint.RuleStdlibShadow.ID:            "Rename packages named like a standard library package, which forces awkward import aliases on code using both, or allow the name with -allow-stdlib-names",
+	doculint.RuleTestFunctionComment.ID:     "Describe the workload a benchmark measures or the corpus a fuzz test explores in its comment",
+	doculint.RuleMultiSentence.ID:           "Document complex functions beyond a sentence echoing their name, e.g. how parameters interact and what edge cases return",
+	doculint.RuleRestatedComment.ID:         "Explain behavior, constraints, or edge cases rather than restating the name, e.g. GetUser returns the user with the given ID, or ErrNotFound if there is none",
+	doculint.RuleDocSyntax.ID:               "Format doc comments with gofmt, mark headings with # explicitly, and close the brackets of doc links; run with -fix to reformat them",
+	doculint.RuleDocBlock.ID:                "Indent code examples and list items in doc comments so that they render as preformatted blocks and lists",
+	doculint.RuleMagicLiteral.ID:            "Replace numeric literals passed to functions or returned by them with named constants explaining their meaning, or exempt the functions with -call-literal-exempt",
+	doculint.RuleNolintReason.ID:            "Explain why issues are suppressed after //nolint comments, as in //nolint:doculint // Generated by protoc.",
+	doculint.RuleConfigField.ID:             "Document every field of Options, Config, and Params structs along with the value used when it is left unset",
+	doculint.RuleDocLanguage.ID:             "Write the documentation of exported declarations in the language given by -language so that every reader of the published documentation can follow it",
+	doculint.RuleGlossary.ID:                "Use the preferred terms of the glossary in the configuration file, run with -fix to replace the deprecated ones",
+	doculint.RuleDocURL.ID:                  "Fix the URLs in doc comments so that they are complete and point to existing pages",
+	doculint.RuleFileHeader.ID:              "Begin every file with the license header given by -header, run with -fix to insert it",
+	doculint.RuleErrorComment.ID:            "Document when functions return an error, e.g. Open returns an error if the file does not exist",
+	doculint.RuleConstructorComment.ID:      "Describe what constructors return by naming the type, e.g. NewClient returns a Client configured with the given options",
+	doculint.RuleAccessorComment.ID:         "Describe what the property of paired getters and setters means rather than echoing their names, e.g. Timeout returns the time allowed for requests, see SetTimeout",
+	doculint.RuleInterfaceContract.ID:       "Describe the contract of interfaces beyond their name, such as what implementations must guarantee and how their methods interact",
+	doculint.RuleDuplicateComment.ID:        "Rewrite comments copied from another declaration to describe the declaration they document",
+	doculint.RuleStaleReference.ID:          "Update comments referring to identifiers that were renamed or removed, or write them as plain words",
+	doculint.RuleWellKnownMethod.ID:         "Mention the interface implemented by methods such as String or MarshalJSON, as in String implements fmt.Stringer, or relax the requirement with -well-known-methods=relaxed",
+	doculint.RuleTaggedFieldComment.ID:      "Document the fields of serialized structs, which define wire formats consumed by other teams",
+}
+
+// writeHints writes a summary of issues to w, tailored to the mix of rules that
+// reported them, with the next steps that can be taken to address them. Nothing is
+// written if there are no issues.
+func writeHints(w io.Writer, issues []issue) {
+	if len(issues) == 0 {
+		return
+	}
+
+	counts := make(map[string]int)
+	var fixable, belowHigh int
+	for i := range issues {
+		counts[issues[i].Rule]++
+
+		if len(issues[i].edits) > 0 {
+			fixable++
+		}
+
+		if issues[i].Confidence != doculint.ConfidenceHigh.String() {
+			belowHigh++
+		}
+	}
+
+	fmt.Fprintf(w, "\ndoculint found %d issues, next steps:\n", len(issues))
+
+	for _, rule := range linter.Rules() {
+		n := counts[rule.ID]
+		if n == 0 {
+			continue
+		}
+
+		hint, ok := ruleHints[rule.ID]
+		if !ok {
+			hint = "Address the issues reported by " + rule.Name
+		}
+
+		fmt.Fprintf(w, "  - %s: %d (%s)\n", hint, n, rule.ID)
+	}
+
+	if fixable > 0 {
+		fmt.Fprintf(w, "  - Run doculint with -fix to fix issues automatically: %d\n", fixable)
+	}
+
+	if belowHigh > 0 {
+		fmt.Fprintf(w, "  - Run doculint with -min-confidence=high to hide issues from heuristic rules: %d\n", belowHigh)
+	}
+}
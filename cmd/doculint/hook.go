@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// hookMarker is written in the pre-commit hooks installed by doculint, so that they can
+// be told apart from hooks written by hand or by other tools.
+const hookMarker = "# Installed by doculint hook install."
+
+// hook installs a git pre-commit hook running doculint on the staged Go files, or
+// writes the equivalent configuration for the pre-commit framework, as named by the
+// first argument of args. The analyzer flags given in args are embedded in the hook. It
+// returns the exit code the command should terminate with.
+func hook(args []string) int {
+	fs := flag.NewFlagSet("hook", flag.ExitOnError)
+	force := fs.Bool("force", false, "overwrite an existing pre-commit hook not installed by doculint")
+	registerAnalyzerFlags(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: doculint hook [-flag] install|config\n\ninstall writes a git pre-commit hook running doculint on the staged Go files.\nconfig writes a .pre-commit-config.yaml entry for the pre-commit framework.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	// The subcommand comes first, as in doculint hook install -period=all.
+	if len(args) == 0 || (args[0] != "install" && args[0] != "config") {
+		fs.Usage()
+		return exitError
+	}
+	_ = fs.Parse(args[1:])
+
+	binary, err := executable()
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+
+	flags, err := setFlags(fs)
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+	flags = removeFlag(flags, "force")
+
+	if args[0] == "config" {
+		writePreCommitConfig(os.Stdout, binary, flags)
+		return exitOK
+	}
+
+	path, err := installHook(binary, flags, *force)
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+
+	log.Printf("installed pre-commit hook in %s", path)
+	return exitOK
+}
+
+// removeFlag returns flags without the -name=value argument of the flag with the given
+// name, which belongs to the hook command rather than to the analyzer.
+func removeFlag(flags []string, name string) []string {
+	kept := flags[:0]
+	for _, f := range flags {
+		if !strings.HasPrefix(f, "-"+name+"=") {
+			kept = append(kept, f)
+		}
+	}
+
+	return kept
+}
+
+// installHook writes the pre-commit hook of the git repository containing the working
+// directory, running binary with flags on the staged Go files, and returns its path.
+// An existing hook is only overwritten if it was installed by doculint or if force is
+// set.
+func installHook(binary string, flags []string, force bool) (string, error) {
+	hooks, err := git("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(strings.TrimSpace(hooks), "pre-commit")
+
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil && !force && !strings.Contains(string(existing), hookMarker):
+		return "", fmt.Errorf("%s already exists and was not installed by doculint, rerun with -force to overwrite it", path)
+	case err != nil && !errors.Is(err, fs.ErrNotExist):
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+
+	var script strings.Builder
+	writeHookScript(&script, binary, flags)
+	if err := os.WriteFile(path, []byte(script.String()), 0o755); err != nil {
+		return "", err
+	}
+
+	// WriteFile keeps the permissions of existing files, which may not be executable.
+	return path, os.Chmod(path, 0o755)
+}
+
+// writeHookScript writes a pre-commit hook script running binary with flags on the Go
+// files added, copied, modified, or renamed in the index, through -staged. doculint is
+// only run on the packages of those files and only reports the issues found in them,
+// which keeps the hook fast. The files are analyzed as found in the index, so that the
+// changes left out of the commit are left out of the analysis too.
+func writeHookScript(w io.Writer, binary string, flags []string) {
+	quoted := []string{shellQuote(binary)}
+	for _, f := range flags {
+		quoted = append(quoted, shellQuote(f))
+	}
+
+	fmt.Fprintf(w, `#!/bin/sh
+%s
+# Runs doculint on the staged Go files, skip it with git commit --no-verify.
+exec %s -staged
+`, hookMarker, strings.Join(quoted, " "))
+}
+
+// writePreCommitConfig writes a .pre-commit-config.yaml entry for the pre-commit
+// framework, which runs binary with flags on the staged Go files.
+func writePreCommitConfig(w io.Writer, binary string, flags []string) {
+	entry := strings.Join(append([]string{binary}, flags...), " ")
+
+	fmt.Fprintf(w, `# .pre-commit-config.yaml
+repos:
+  - repo: local
+    hooks:
+      - id: doculint
+        name: doculint
+        entry: %s
+        language: system
+        types: [go]
+`, jsonString(entry))
+}
+
+// shellQuote quotes s for use as a single word in a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// memoryLimitUsage is the usage of the -memory-limit flag, shared by the subcommands.
+const memoryLimitUsage = "soft limit of the memory used by doculint, such as 512MiB or 2GB, past which the garbage collector runs more often; defaults to $GOMEMLIMIT"
+
+// batchLoadMode is the mode packages are listed with to split them in batches, which
+// only runs the build system's query tool.
+const batchLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles
+
+// sizeUnits are the multipliers of the units accepted by -memory-limit.
+var sizeUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+// memoryLimit is the value of the -memory-limit flag, which sets the soft memory limit
+// of the runtime as soon as it is parsed.
+type memoryLimit struct {
+	// value is the limit as given on the command line, empty if it was not.
+	value string
+}
+
+// String returns the limit as given on the command line.
+func (m *memoryLimit) String() string {
+	return m.value
+}
+
+// Set parses value as a number of bytes followed by an optional unit, such as 512MiB,
+// and sets the soft memory limit of the runtime to it.
+func (m *memoryLimit) Set(value string) error {
+	number := strings.TrimRight(value, "BKMGTi")
+	unit, ok := sizeUnits[value[len(number):]]
+	if !ok {
+		return fmt.Errorf("unknown unit in \"%s\", expected B, KB, MB, GB, TB, KiB, MiB, GiB, or TiB", value)
+	}
+
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || n <= 0 || n > math.MaxInt64/unit {
+		return fmt.Errorf("invalid memory limit \"%s\"", value)
+	}
+
+	debug.SetMemoryLimit(n * unit)
+	m.value = value
+	return nil
+}
+
+// batchPatterns splits the packages matching patterns into batches holding up to
+// maxFiles Go files in total, so that the packages of a batch can be loaded, analyzed,
+// and released before those of the next one are loaded. A package with more files than
+// maxFiles is a batch of its own. The test variants of a package are in the batch of
+// the package, whose files they share. A single batch of patterns is returned if
+// maxFiles is zero or negative, or if files outside of any module are among them.
+func batchPatterns(patterns []string, maxFiles int) ([][]string, error) {
+	if maxFiles <= 0 {
+		return [][]string{patterns}, nil
+	}
+
+	listed, err := packages.Load(&packages.Config{
+		Mode:    batchLoadMode,
+		Tests:   *includeTests,
+		Overlay: overlay,
+	}, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The files of a package are counted along with those of its test variants, which
+	// share its directory and are loaded with it.
+	var paths []string
+	dirs := make(map[string]string)
+	files := make(map[string]map[string]bool)
+	for _, pkg := range listed {
+		if pkg.PkgPath == "command-line-arguments" {
+			// Packages made of files outside of any module can only be loaded through
+			// the original patterns.
+			return [][]string{patterns}, nil
+		}
+
+		if files[pkg.Dir] == nil {
+			files[pkg.Dir] = make(map[string]bool)
+		}
+		for _, name := range pkg.CompiledGoFiles {
+			files[pkg.Dir][name] = true
+		}
+
+		if pkg.ID == pkg.PkgPath && !strings.HasSuffix(pkg.ID, ".test") {
+			paths = append(paths, pkg.PkgPath)
+			dirs[pkg.PkgPath] = pkg.Dir
+		}
+	}
+
+	var batches [][]string
+	var batch []string
+	var size int
+	for _, path := range paths {
+		n := len(files[dirs[path]])
+		if len(batch) > 0 && size+n > maxFiles {
+			batches = append(batches, batch)
+			batch, size = nil, 0
+		}
+
+		batch = append(batch, path)
+		size += n
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+// analyzeBatches loads the packages matching patterns and analyzes them in batches of
+// up to -max-files Go files, calling each with the graph of every batch once it is
+// analyzed, after which its packages are released. Since facts are only shared by the
+// packages of a batch, findings depending on the documentation of imported packages,
+// such as those of -upstream-docs, are only reported for imports in the same batch. The
+// errors of the packages are printed, and the returned boolean reports whether there
+// were any.
+func analyzeBatches(patterns []string, each func(graph *checker.Graph)) (bool, error) {
+	batches, err := batchPatterns(patterns, *maxFiles)
+	if err != nil {
+		return false, err
+	}
+
+	var failed bool
+	var loaded int
+	for _, batch := range batches {
+		pkgs, err := packages.Load(&packages.Config{
+			Mode:    packages.LoadSyntax | packages.NeedModule,
+			Tests:   *includeTests,
+			Overlay: overlay,
+		}, batch...)
+		if err != nil {
+			return failed, err
+		}
+		loaded += len(pkgs)
+
+		if packages.PrintErrors(pkgs) > 0 {
+			failed = true
+		}
+
+		graph, err := analyzePackages(pkgs)
+		if err != nil {
+			return failed, err
+		}
+
+		each(graph)
+	}
+
+	if loaded == 0 {
+		return failed, fmt.Errorf("%v matched no packages", patterns)
+	}
+
+	return failed, nil
+}
@@ -0,0 +1,485 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/george-e-shaw-iv/doculint/internal/doculint"
+)
+
+// Language Server Protocol constants used by the lsp subcommand.
+const (
+	// lspSyncNone tells the client not to send the content of documents, which are
+	// analyzed from disk when opened or saved.
+	lspSyncNone = 0
+
+	// lspSeverityWarning is the severity of the diagnostics of high confidence rules.
+	lspSeverityWarning = 2
+
+	// lspSeverityInformation is the severity of the diagnostics of heuristic rules.
+	lspSeverityInformation = 3
+
+	// lspMessageError is the type of window/logMessage notifications reporting errors.
+	lspMessageError = 1
+)
+
+// lspRequest is a JSON-RPC 2.0 request or notification sent by a language client.
+// Notifications have no ID.
+type lspRequest struct {
+	// ID identifies the request, it is echoed back in the response.
+	ID json.RawMessage `json:"id,omitempty"`
+
+	// Method is the name of the method to call.
+	Method string `json:"method"`
+
+	// Params are the parameters of the method.
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// lspResponse is a successful JSON-RPC 2.0 response, whose result is always present,
+// even when null, as required by the Language Server Protocol.
+type lspResponse struct {
+	// JSONRPC is the version of the protocol, always "2.0".
+	JSONRPC string `json:"jsonrpc"`
+
+	// ID is the ID of the request being responded to.
+	ID json.RawMessage `json:"id"`
+
+	// Result is the result of the method.
+	Result interface{} `json:"result"`
+}
+
+// lspNotification is a JSON-RPC 2.0 notification sent to the language client.
+type lspNotification struct {
+	// JSONRPC is the version of the protocol, always "2.0".
+	JSONRPC string `json:"jsonrpc"`
+
+	// Method is the name of the notification.
+	Method string `json:"method"`
+
+	// Params are the parameters of the notification.
+	Params interface{} `json:"params"`
+}
+
+// lspPosition is a zero-based line and UTF-16 character offset in a document.
+type lspPosition struct {
+	// Line is the zero-based line of the position.
+	Line int `json:"line"`
+
+	// Character is the zero-based offset of the position in the line, in UTF-16 code
+	// units.
+	Character int `json:"character"`
+}
+
+// lspRange is a range in a document, end exclusive.
+type lspRange struct {
+	// Start is the start of the range.
+	Start lspPosition `json:"start"`
+
+	// End is the end of the range.
+	End lspPosition `json:"end"`
+}
+
+// lspDiagnostic is an issue published to the language client.
+type lspDiagnostic struct {
+	// Range is the range of the document the issue applies to.
+	Range lspRange `json:"range"`
+
+	// Severity is lspSeverityWarning or lspSeverityInformation, depending on the
+	// confidence of the rule that reported the issue.
+	Severity int `json:"severity"`
+
+	// Code is the ID of the rule that reported the issue.
+	Code string `json:"code"`
+
+	// CodeDescription links to the documentation of the rule that reported the issue.
+	CodeDescription *lspCodeDescription `json:"codeDescription,omitempty"`
+
+	// Source is always "doculint".
+	Source string `json:"source"`
+
+	// Message is the human readable description of the issue.
+	Message string `json:"message"`
+}
+
+// lspCodeDescription links a diagnostic to the documentation of its rule.
+type lspCodeDescription struct {
+	// Href is the URL of the documentation.
+	Href string `json:"href"`
+}
+
+// lspTextEdit is a replacement of a range of a document.
+type lspTextEdit struct {
+	// Range is the range replaced.
+	Range lspRange `json:"range"`
+
+	// NewText is the replacement text.
+	NewText string `json:"newText"`
+}
+
+// lspCodeAction is a quick fix applying the suggested fix of an issue.
+type lspCodeAction struct {
+	// Title describes the action in the menu of the editor.
+	Title string `json:"title"`
+
+	// Kind is always "quickfix".
+	Kind string `json:"kind"`
+
+	// Diagnostics are the diagnostics the action fixes.
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+
+	// Edit is the change applied by the action, as text edits keyed by document URI.
+	Edit struct {
+		Changes map[string][]lspTextEdit `json:"changes"`
+	} `json:"edit"`
+}
+
+// lspDocumentParams are the parameters of the textDocument notifications and requests
+// handled by the language server.
+type lspDocumentParams struct {
+	// TextDocument identifies the document.
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+
+	// Range is the range code actions are requested for.
+	Range lspRange `json:"range"`
+}
+
+// lspServer is a language server publishing the issues found by doculint to an editor
+// over stdio. Documents are analyzed from disk when opened and saved, along with the
+// rest of their package, and the suggested fixes of issues are offered as quick fixes.
+// The diagnostics of documents are cleared when they are closed.
+type lspServer struct {
+	// w is where messages are written to the client.
+	w io.Writer
+
+	// issues maps the URI of each document diagnostics were published for to the issues
+	// found in it.
+	issues map[string][]issue
+}
+
+// lsp runs the doculint language server on stdin and stdout until the client asks it to
+// exit. It returns the exit code the command should terminate with.
+func lsp(args []string) int {
+	fs := flag.NewFlagSet("lsp", flag.ExitOnError)
+	fs.BoolVar(includeTests, "test", true, "indicates whether test files should be analyzed, too")
+	fs.IntVar(concurrency, "concurrency", 0, concurrencyUsage)
+	fs.Var(&memLimit, "memory-limit", memoryLimitUsage)
+	registerAnalyzerFlags(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: doculint lsp [-flag]\n\nRuns a language server over stdio, publishing diagnostics when files are opened or saved, and clearing them when files are closed.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	s := &lspServer{w: os.Stdout, issues: make(map[string][]issue)}
+	r := bufio.NewReader(os.Stdin)
+
+	shutdown := false
+	for {
+		req, err := readLSPMessage(r)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				log.Print(err)
+			}
+			return exitError
+		}
+
+		switch req.Method {
+		case "initialize":
+			s.respond(req.ID, map[string]interface{}{
+				"capabilities": map[string]interface{}{
+					"textDocumentSync": map[string]interface{}{
+						"openClose": true,
+						"change":    lspSyncNone,
+						"save":      true,
+					},
+					"codeActionProvider": true,
+				},
+				"serverInfo": map[string]string{"name": "doculint"},
+			})
+		case "shutdown":
+			shutdown = true
+			s.respond(req.ID, nil)
+		case "exit":
+			if shutdown {
+				return exitOK
+			}
+			return exitError
+		case "textDocument/didOpen", "textDocument/didSave":
+			var params lspDocumentParams
+			if err := json.Unmarshal(req.Params, &params); err == nil {
+				s.lint(params.TextDocument.URI)
+			}
+		case "textDocument/didClose":
+			var params lspDocumentParams
+			if err := json.Unmarshal(req.Params, &params); err == nil {
+				s.close(params.TextDocument.URI)
+			}
+		case "textDocument/codeAction":
+			var params lspDocumentParams
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				s.respond(req.ID, []lspCodeAction{})
+				continue
+			}
+			s.respond(req.ID, s.codeActions(params.TextDocument.URI, params.Range))
+		default:
+			if len(req.ID) > 0 {
+				// Requests must be answered, even those the server does not support.
+				s.respond(req.ID, nil)
+			}
+		}
+	}
+}
+
+// readLSPMessage reads a message framed by a Content-Length header from r.
+func readLSPMessage(r *bufio.Reader) (lspRequest, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return lspRequest{}, err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(name, "Content-Length") {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				return lspRequest{}, fmt.Errorf("invalid Content-Length \"%s\"", value)
+			}
+		}
+	}
+
+	if length < 0 {
+		return lspRequest{}, errors.New("message without Content-Length header")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return lspRequest{}, err
+	}
+
+	var req lspRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return lspRequest{}, err
+	}
+
+	return req, nil
+}
+
+// send writes msg to the client, framed by a Content-Length header.
+func (s *lspServer) send(msg interface{}) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	fmt.Fprintf(s.w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+// respond sends the result of the request with the given ID.
+func (s *lspServer) respond(id json.RawMessage, result interface{}) {
+	s.send(lspResponse{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+// notify sends a notification to the client.
+func (s *lspServer) notify(method string, params interface{}) {
+	s.send(lspNotification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// lint analyzes the package of the document with the given URI and publishes the
+// diagnostics of every file of the package, including those without issues so that
+// fixed issues are cleared.
+func (s *lspServer) lint(uri string) {
+	path, err := uriPath(uri)
+	if err != nil || !strings.HasSuffix(path, ".go") {
+		return
+	}
+
+	pkgs, _, err := load(filepath.Dir(path), []string{path})
+	if err != nil {
+		s.notify("window/logMessage", map[string]interface{}{"type": lspMessageError, "message": err.Error()})
+		return
+	}
+
+	// Every issue of the package is published, not only those of the document, since
+	// publishing replaces the diagnostics of a file.
+	issues, err := analyze(pkgs, nil)
+	if err != nil {
+		s.notify("window/logMessage", map[string]interface{}{"type": lspMessageError, "message": err.Error()})
+	}
+
+	byURI := make(map[string][]issue)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.CompiledGoFiles {
+			byURI[pathURI(file)] = nil
+		}
+	}
+	for _, is := range issues {
+		if is.position.IsValid() {
+			u := pathURI(is.position.Filename)
+			byURI[u] = append(byURI[u], is)
+		}
+	}
+
+	uris := make([]string, 0, len(byURI))
+	for u := range byURI {
+		uris = append(uris, u)
+	}
+	sort.Strings(uris)
+
+	for _, u := range uris {
+		s.issues[u] = byURI[u]
+
+		diagnostics := []lspDiagnostic{}
+		for _, is := range byURI[u] {
+			if d, ok := lspDiagnosticOf(is); ok {
+				diagnostics = append(diagnostics, d)
+			}
+		}
+
+		s.notify("textDocument/publishDiagnostics", map[string]interface{}{"uri": u, "diagnostics": diagnostics})
+	}
+}
+
+// close forgets the issues of the document with the given URI and publishes an empty
+// list of diagnostics for it, so that the client stops showing the issues of a closed
+// document until it is opened again.
+func (s *lspServer) close(uri string) {
+	delete(s.issues, uri)
+	s.notify("textDocument/publishDiagnostics", map[string]interface{}{"uri": uri, "diagnostics": []lspDiagnostic{}})
+}
+
+// codeActions returns the quick fixes for the issues of the document with the given URI
+// that overlap rng and carry a suggested fix.
+func (s *lspServer) codeActions(uri string, rng lspRange) []lspCodeAction {
+	actions := []lspCodeAction{}
+	for _, is := range s.issues[uri] {
+		if len(is.edits) == 0 {
+			continue
+		}
+
+		d, ok := lspDiagnosticOf(is)
+		if !ok || d.Range.End.Line < rng.Start.Line || d.Range.Start.Line > rng.End.Line {
+			continue
+		}
+
+		action := lspCodeAction{
+			Title:       "Fix: " + is.Message,
+			Kind:        "quickfix",
+			Diagnostics: []lspDiagnostic{d},
+		}
+		action.Edit.Changes = make(map[string][]lspTextEdit)
+
+		for _, e := range is.edits {
+			content, err := os.ReadFile(e.file)
+			if err != nil {
+				continue
+			}
+
+			u := pathURI(e.file)
+			action.Edit.Changes[u] = append(action.Edit.Changes[u], lspTextEdit{
+				Range:   lspRange{Start: lspPositionOf(content, e.start), End: lspPositionOf(content, e.end)},
+				NewText: string(e.text),
+			})
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions
+}
+
+// lspDiagnosticOf converts is to a diagnostic, reading its file to resolve its range to
+// UTF-16 offsets. It returns false if the file cannot be read.
+func lspDiagnosticOf(is issue) (lspDiagnostic, bool) {
+	content, err := os.ReadFile(is.position.Filename)
+	if err != nil {
+		return lspDiagnostic{}, false
+	}
+
+	end := is.position.Offset
+	if is.end.IsValid() && is.end.Filename == is.position.Filename {
+		end = is.end.Offset
+	}
+
+	severity := lspSeverityInformation
+	if is.Confidence == doculint.ConfidenceHigh.String() {
+		severity = lspSeverityWarning
+	}
+
+	d := lspDiagnostic{
+		Range:    lspRange{Start: lspPositionOf(content, is.position.Offset), End: lspPositionOf(content, end)},
+		Severity: severity,
+		Code:     is.Rule,
+		Source:   "doculint",
+		Message:  is.Message,
+	}
+	if is.URL != "" {
+		d.CodeDescription = &lspCodeDescription{Href: is.URL}
+	}
+
+	return d, true
+}
+
+// lspPositionOf converts the byte offset of content to a line and UTF-16 character
+// offset.
+func lspPositionOf(content []byte, offset int) lspPosition {
+	offset = min(max(offset, 0), len(content))
+
+	var pos lspPosition
+	for i := 0; i < offset; {
+		r, size := utf8.DecodeRune(content[i:])
+		i += size
+
+		switch {
+		case r == '\n':
+			pos.Line++
+			pos.Character = 0
+		case r >= 0x10000:
+			// Runes outside the basic multilingual plane take a surrogate pair.
+			pos.Character += 2
+		default:
+			pos.Character++
+		}
+	}
+
+	return pos
+}
+
+// uriPath returns the path of the file with the given file URI.
+func uriPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported URI \"%s\", only file URIs are supported", uri)
+	}
+
+	return filepath.FromSlash(u.Path), nil
+}
+
+// pathURI returns the file URI of the file at path.
+func pathURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// TestLSPClose closes a document with issues, verifying that its issues are forgotten
+// and that an empty list of diagnostics is published for it.
+func TestLSPClose(t *testing.T) {
+	var out bytes.Buffer
+	uri := pathURI("/r/p.go")
+	s := &lspServer{w: &out, issues: map[string][]issue{uri: {{Rule: "DL001"}}}}
+
+	s.close(uri)
+
+	if _, ok := s.issues[uri]; ok {
+		t.Errorf("issues of %s were kept after it was closed", uri)
+	}
+
+	msg, err := readLSPMessage(bufio.NewReader(&out))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var params struct {
+		URI         string            `json:"uri"`
+		Diagnostics []json.RawMessage `json:"diagnostics"`
+	}
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Method != "textDocument/publishDiagnostics" || params.URI != uri || params.Diagnostics == nil || len(params.Diagnostics) != 0 {
+		t.Errorf("published %s %s, want an empty list of diagnostics for %s", msg.Method, msg.Params, uri)
+	}
+}
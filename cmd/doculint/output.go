@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"sort"
+
+	"github.com/george-e-shaw-iv/doculint/internal/doculint"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// issue is a single finding reported by the analyzer, resolved to a file position and
+// annotated with the rule that reported it.
+type issue struct {
+	// Rule is the ID of the rule that reported the issue.
+	Rule string `json:"rule"`
+
+	// Confidence is the confidence of the rule that reported the issue.
+	Confidence string `json:"confidence"`
+
+	// Posn is the position of the issue in file:line:column form.
+	Posn string `json:"posn"`
+
+	// End is the end of the range of the issue in file:line:column form, if the rule
+	// reported one, such as the identifier or comment in question.
+	End string `json:"end,omitempty"`
+
+	// Message is the human readable description of the issue.
+	Message string `json:"message"`
+
+	// URL is the URL of the documentation of the rule that reported the issue.
+	URL string `json:"url,omitempty"`
+
+	// Related are the other locations involved in the issue, such as the comment of a
+	// declaration reported for not beginning with its name.
+	Related []related `json:"related,omitempty"`
+
+	// position is the resolved position of the issue, used for de-duplication.
+	position token.Position
+
+	// end is the resolved end of the range of the issue, if the rule reported one.
+	end token.Position
+
+	// edits are the text edits of the first suggested fix of the issue, if any.
+	edits []edit
+}
+
+// related is a location involved in an issue other than its position.
+type related struct {
+	// Posn is the position of the location in file:line:column form.
+	Posn string `json:"posn"`
+
+	// Message describes the location.
+	Message string `json:"message"`
+
+	// position and end are the resolved range of the location, end being invalid if
+	// the location is a single position.
+	position, end token.Position
+}
+
+// collect gathers the issues reported for the root packages of graph that are kept by
+// files. Issues reported more than once, which happens for files belonging to both a
+// package and its test variant, are only returned once. Any analysis errors are joined
+// and returned alongside the issues that could be collected. Issues are ordered by file
+// and position, then by rule and message, so that the output is stable across runs.
+func collect(graph *checker.Graph, files fileFilter) ([]issue, error) {
+	var issues []issue
+	var errs []error
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", act.Package.ID, act.Err))
+			continue
+		}
+
+		issues = append(issues, files.filter(act.Package, packageIssues(act))...)
+	}
+
+	return mergeIssues(issues), errors.Join(errs...)
+}
+
+// packageIssues converts the diagnostics reported for the package of act to issues, in
+// the order they were reported.
+func packageIssues(act *checker.Action) []issue {
+	fset := act.Package.Fset
+
+	issues := make([]issue, 0, len(act.Diagnostics))
+	for _, diag := range act.Diagnostics {
+		position := fset.Position(diag.Pos)
+
+		var end token.Position
+		if diag.End.IsValid() {
+			end = fset.Position(diag.End)
+		}
+
+		var rel []related
+		for _, info := range diag.Related {
+			r := related{
+				Posn:     fset.Position(info.Pos).String(),
+				Message:  info.Message,
+				position: fset.Position(info.Pos),
+			}
+			if info.End.IsValid() {
+				r.end = fset.Position(info.End)
+			}
+			rel = append(rel, r)
+		}
+
+		confidence := doculint.ConfidenceHigh
+		if rule, ok := linter.LookupRule(diag.Category); ok {
+			confidence = rule.Confidence
+		}
+
+		issues = append(issues, issue{
+			Rule:       diag.Category,
+			Confidence: confidence.String(),
+			Posn:       position.String(),
+			End:        endPosn(end),
+			Message:    diag.Message,
+			URL:        diag.URL,
+			Related:    rel,
+			position:   position,
+			end:        end,
+			edits:      resolveEdits(fset, diag),
+		})
+	}
+
+	return issues
+}
+
+// mergeIssues removes the duplicates of issues, which are reported for the files
+// belonging to both a package and its test variant, and orders the remaining issues by
+// file and position, then by rule and message.
+func mergeIssues(issues []issue) []issue {
+	type key struct {
+		position token.Position
+		message  string
+	}
+	seen := make(map[key]bool)
+
+	merged := make([]issue, 0, len(issues))
+	for _, is := range issues {
+		k := key{is.position, is.Message}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+
+		merged = append(merged, is)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.position.Filename != b.position.Filename {
+			return a.position.Filename < b.position.Filename
+		}
+		if a.position.Offset != b.position.Offset {
+			return a.position.Offset < b.position.Offset
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Message < b.Message
+	})
+
+	return merged
+}
+
+// endPosn returns end in file:line:column form, or an empty string if the issue has no
+// range.
+func endPosn(end token.Position) string {
+	if !end.IsValid() {
+		return ""
+	}
+
+	return end.String()
+}
+
+// writeText writes issues to w as plain text, one issue per line followed by their
+// related locations, indented.
+func writeText(w io.Writer, issues []issue) {
+	for i := range issues {
+		fmt.Fprintf(w, "%s: %s\n", issues[i].Posn, issues[i].Message)
+		for _, rel := range issues[i].Related {
+			fmt.Fprintf(w, "\t%s: %s\n", rel.Posn, rel.Message)
+		}
+	}
+}
+
+// writePlain writes issues to w one per line as path:line:column: message (rule), the
+// form understood by Vim's quickfix list, Emacs' compilation mode, and generic problem
+// matchers. Related locations are left out, since these tools would list each of them
+// as an issue of its own. Paths are relative to the working directory when possible,
+// and columns are one-based byte offsets.
+func writePlain(w io.Writer, issues []issue) error {
+	bw := bufio.NewWriter(w)
+	for i := range issues {
+		is := &issues[i]
+		fmt.Fprintf(bw, "%s: %s (%s)\n", plainPosn(is.position), is.Message, is.Rule)
+	}
+
+	return bw.Flush()
+}
+
+// plainPosn returns pos in path:line:column form, with the path relative to the working
+// directory when possible and the column defaulting to 1 if unknown.
+func plainPosn(pos token.Position) string {
+	if !pos.IsValid() {
+		return "-"
+	}
+
+	return fmt.Sprintf("%s:%d:%d", relativePath(pos.Filename), pos.Line, max(pos.Column, 1))
+}
+
+// formats maps the names of the machine readable output formats accepted by -format to
+// the function writing issues in that format to stdout.
+var formats = map[string]func(w io.Writer, issues []issue) error{
+	"plain":     writePlain,
+	"json":      writeJSON,
+	"rdjson":    writeRDJSON,
+	"gerrit":    writeGerrit,
+	"sonarqube": writeSonar,
+
+	"codeclimate":        writeCodeClimate,
+	"codeclimate-engine": writeCodeClimateEngine,
+}
+
+// writeJSON writes issues to w as an indented JSON array.
+func writeJSON(w io.Writer, issues []issue) error {
+	if issues == nil {
+		issues = []issue{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(issues)
+}
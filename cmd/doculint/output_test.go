@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// update controls whether the golden files of the tests are rewritten with the output
+// of the tests rather than compared to it.
+var update = flag.Bool("update", false, "rewrite the golden files of the tests")
+
+// formatTests are the formats of -format whose output is compared to a golden file.
+var formatTests = []string{"json", "rdjson", "gerrit", "sonarqube", "codeclimate", "codeclimate-engine", "plain"}
+
+// TestFormats writes the issues of the package in testdata/widget in every format of
+// formatTests, comparing the output to the golden file testdata/<format>.golden. Absolute
+// paths are written relative to the package directory, and $BUILD_ID is set, so that
+// the golden files do not depend on where or when the tests run.
+func TestFormats(t *testing.T) {
+	t.Setenv("BUILD_ID", "test")
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadSyntax | packages.NeedModule}, "./testdata/widget")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		t.Fatal("testdata/widget has errors")
+	}
+
+	graph, err := analyzePackages(pkgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues, err := collect(graph, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range formatTests {
+		t.Run(name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := formats[name](&b, issues); err != nil {
+				t.Fatal(err)
+			}
+			got := strings.ReplaceAll(b.String(), wd+string(filepath.Separator), "")
+
+			golden := filepath.Join("testdata", name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("output does not match %s, run with -update to rewrite it:\n%s", golden, got)
+			}
+		})
+	}
+}
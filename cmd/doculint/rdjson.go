@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/george-e-shaw-iv/doculint/internal/doculint"
+)
+
+// rdResult is a reviewdog Diagnostic Format (rdjson) result, see
+// https://github.com/reviewdog/reviewdog/tree/master/proto/rdf.
+type rdResult struct {
+	// Source is the tool that reported the diagnostics.
+	Source rdSource `json:"source"`
+
+	// Diagnostics are the reported issues.
+	Diagnostics []rdDiagnostic `json:"diagnostics"`
+}
+
+// rdSource identifies the tool that reported a diagnostic.
+type rdSource struct {
+	// Name is always "doculint".
+	Name string `json:"name"`
+
+	// URL is the URL of the documentation of the tool.
+	URL string `json:"url,omitempty"`
+}
+
+// rdDiagnostic is an issue in rdjson form.
+type rdDiagnostic struct {
+	// Message is the human readable description of the issue.
+	Message string `json:"message"`
+
+	// Location is where the issue was found.
+	Location rdLocation `json:"location"`
+
+	// Severity is WARNING for the issues of high confidence rules, INFO otherwise.
+	Severity string `json:"severity"`
+
+	// Code is the rule that reported the issue.
+	Code rdCode `json:"code"`
+
+	// Suggestions are the edits of the suggested fix of the issue, if any.
+	Suggestions []rdSuggestion `json:"suggestions,omitempty"`
+
+	// RelatedLocations are the other locations involved in the issue.
+	RelatedLocations []rdRelatedLocation `json:"related_locations,omitempty"`
+}
+
+// rdLocation is a range of a file.
+type rdLocation struct {
+	// Path is the path of the file, relative to the working directory when possible.
+	Path string `json:"path"`
+
+	// Range is the range of the file, whose end is omitted for issues reported at a
+	// single position.
+	Range rdRange `json:"range"`
+}
+
+// rdRange is a range of a file, end exclusive.
+type rdRange struct {
+	// Start is the start of the range.
+	Start rdPosition `json:"start"`
+
+	// End is the end of the range, if any.
+	End *rdPosition `json:"end,omitempty"`
+}
+
+// rdPosition is a one-based line and byte column in a file.
+type rdPosition struct {
+	// Line is the one-based line of the position.
+	Line int `json:"line"`
+
+	// Column is the one-based byte offset of the position in its line.
+	Column int `json:"column"`
+}
+
+// rdCode identifies the rule that reported an issue.
+type rdCode struct {
+	// Value is the ID of the rule.
+	Value string `json:"value"`
+
+	// URL is the URL of the documentation of the rule.
+	URL string `json:"url,omitempty"`
+}
+
+// rdSuggestion is an edit of a suggested fix.
+type rdSuggestion struct {
+	// Range is the range replaced.
+	Range rdRange `json:"range"`
+
+	// Text is the replacement text.
+	Text string `json:"text"`
+}
+
+// rdRelatedLocation is a location involved in an issue other than its position.
+type rdRelatedLocation struct {
+	// Message describes the location.
+	Message string `json:"message"`
+
+	// Location is the location.
+	Location rdLocation `json:"location"`
+}
+
+// writeRDJSON writes issues to w in the reviewdog Diagnostic Format, so that doculint
+// can be run with reviewdog -f=rdjson. Issues without a position are skipped, since
+// reviewdog attaches every diagnostic to a file.
+func writeRDJSON(w io.Writer, issues []issue) error {
+	result := rdResult{
+		Source:      rdSource{Name: "doculint", URL: linter.Analyzer.URL},
+		Diagnostics: []rdDiagnostic{},
+	}
+
+	for i := range issues {
+		is := &issues[i]
+		if !is.position.IsValid() {
+			continue
+		}
+
+		severity := "INFO"
+		if is.Confidence == doculint.ConfidenceHigh.String() {
+			severity = "WARNING"
+		}
+
+		d := rdDiagnostic{
+			Message:  is.Message,
+			Location: rdLocationOf(is.position, is.end),
+			Severity: severity,
+			Code:     rdCode{Value: is.Rule, URL: is.URL},
+		}
+
+		for _, rel := range is.Related {
+			if rel.position.IsValid() {
+				d.RelatedLocations = append(d.RelatedLocations, rdRelatedLocation{
+					Message:  rel.Message,
+					Location: rdLocationOf(rel.position, rel.end),
+				})
+			}
+		}
+
+		for _, e := range is.edits {
+			content, err := os.ReadFile(e.file)
+			if err != nil {
+				continue
+			}
+
+			end := rdPositionOf(content, e.end)
+			d.Suggestions = append(d.Suggestions, rdSuggestion{
+				Range: rdRange{Start: rdPositionOf(content, e.start), End: &end},
+				Text:  string(e.text),
+			})
+		}
+
+		result.Diagnostics = append(result.Diagnostics, d)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(result)
+}
+
+// rdLocationOf returns the location spanning from start to end, which may be invalid
+// for issues reported at a single position.
+func rdLocationOf(start, end token.Position) rdLocation {
+	loc := rdLocation{
+		Path:  relativePath(start.Filename),
+		Range: rdRange{Start: rdPosition{Line: start.Line, Column: start.Column}},
+	}
+
+	if end.IsValid() && end.Filename == start.Filename {
+		loc.Range.End = &rdPosition{Line: end.Line, Column: end.Column}
+	}
+
+	return loc
+}
+
+// rdPositionOf converts the byte offset of content to a one-based line and byte column.
+func rdPositionOf(content []byte, offset int) rdPosition {
+	offset = min(max(offset, 0), len(content))
+
+	pos := rdPosition{Line: 1, Column: 1}
+	for _, b := range content[:offset] {
+		if b == '\n' {
+			pos.Line++
+			pos.Column = 1
+		} else {
+			pos.Column++
+		}
+	}
+
+	return pos
+}
+
+// relativePath returns path relative to the working directory if it is within it, or
+// path unchanged otherwise.
+func relativePath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return path
+	}
+
+	return filepath.ToSlash(rel)
+}
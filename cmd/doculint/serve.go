@@ -0,0 +1,472 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// JSON-RPC 2.0 error codes returned by the server.
+const (
+	// rpcParseError is returned when the request body is not valid JSON.
+	rpcParseError = -32700
+
+	// rpcInvalidRequest is returned when the request is not a valid JSON-RPC request.
+	rpcInvalidRequest = -32600
+
+	// rpcMethodNotFound is returned when the requested method does not exist.
+	rpcMethodNotFound = -32601
+
+	// rpcInvalidParams is returned when the parameters of a request are invalid.
+	rpcInvalidParams = -32602
+
+	// rpcInternalError is returned when the request could not be completed.
+	rpcInternalError = -32603
+)
+
+// maxCachedResults is the number of results the server caches, past which the least
+// recently used one is evicted.
+const maxCachedResults = 64
+
+// stampLoadMode is the mode the packages whose files are stamped are listed with, which
+// only runs the build system's query tool.
+const stampLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedModule
+
+// rpcRequest is a JSON-RPC 2.0 request.
+type rpcRequest struct {
+	// JSONRPC is the version of the protocol, which must be "2.0".
+	JSONRPC string `json:"jsonrpc"`
+
+	// ID identifies the request, it is echoed back in the response.
+	ID json.RawMessage `json:"id,omitempty"`
+
+	// Method is the name of the method to call.
+	Method string `json:"method"`
+
+	// Params are the parameters of the method.
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response.
+type rpcResponse struct {
+	// JSONRPC is the version of the protocol, always "2.0".
+	JSONRPC string `json:"jsonrpc"`
+
+	// ID is the ID of the request being responded to.
+	ID json.RawMessage `json:"id"`
+
+	// Result is the result of the method, if it succeeded.
+	Result interface{} `json:"result,omitempty"`
+
+	// Error describes why the method failed, if it did.
+	Error *rpcError `json:"error,omitempty"`
+}
+
+// rpcError is the error of a failed JSON-RPC 2.0 request.
+type rpcError struct {
+	// Code is one of the JSON-RPC 2.0 error codes.
+	Code int `json:"code"`
+
+	// Message describes the error.
+	Message string `json:"message"`
+}
+
+// rpcParams are the parameters accepted by the methods of the server.
+type rpcParams struct {
+	// Dir is the directory patterns and files are resolved relative to, defaulting to
+	// the working directory of the server.
+	Dir string `json:"dir"`
+
+	// Patterns are the package patterns analyzed by lintPackage and coverage.
+	Patterns []string `json:"patterns"`
+
+	// File is the path of the Go file analyzed by lintFile.
+	File string `json:"file"`
+}
+
+// lintResult is the result of the lintPackage and lintFile methods.
+type lintResult struct {
+	// Issues are the issues found.
+	Issues []issue `json:"issues"`
+
+	// Errors are the errors encountered loading or analyzing the packages.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// coverageResult is the result of the coverage method.
+type coverageResult struct {
+	// Coverage is the documentation coverage of each package.
+	Coverage []coverage `json:"coverage"`
+
+	// Errors are the errors encountered loading the packages.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// stamp records the state of a file or directory when a result was cached, to detect
+// whether it has changed since.
+type stamp struct {
+	// modTime is the modification time of the file or directory.
+	modTime time.Time
+
+	// size is the size of the file or directory.
+	size int64
+}
+
+// cached is a cached result along with the state of the files it was computed from.
+type cached struct {
+	// result is the cached result.
+	result interface{}
+
+	// stamps are the states of the files and directories of the packages the result
+	// was computed from, keyed by path.
+	stamps map[string]stamp
+
+	// used is when the result was last stored or returned, to evict the least recently
+	// used result.
+	used time.Time
+}
+
+// server is a long-lived doculint process answering JSON-RPC 2.0 requests over HTTP.
+// Results are cached until one of the files or directories of the packages they were
+// computed from, or of the packages those import, or the -config file changes, so
+// repeated requests avoid reloading and reanalyzing packages. Up to maxCachedResults
+// results are cached. The configuration is reloaded when the -config file changes.
+type server struct {
+	// mu guards cache and config.
+	mu sync.Mutex
+
+	// cache maps a method and its parameters to its cached result.
+	cache map[string]*cached
+
+	// config is the state of the -config file when the linter last reloaded it.
+	config stamp
+
+	// linterMu is held for reading by the requests loading and analyzing packages, and
+	// for writing while the linter reloads its configuration.
+	linterMu sync.RWMutex
+}
+
+// serve runs the doculint server, listening on the address given through the -addr
+// flag, until it is interrupted. It returns the exit code the command should terminate
+// with.
+func serve(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:7777", "address to listen on")
+	fs.BoolVar(includeTests, "test", true, "indicates whether test files should be analyzed, too")
+	fs.IntVar(concurrency, "concurrency", 0, concurrencyUsage)
+	fs.Var(&memLimit, "memory-limit", memoryLimitUsage)
+	registerAnalyzerFlags(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: doculint serve [-flag]\n\nServes the lintPackage, lintFile, and coverage JSON-RPC 2.0 methods over HTTP.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	s := &server{cache: make(map[string]*cached)}
+	httpServer := &http.Server{Addr: *addr, Handler: s}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		_ = httpServer.Shutdown(context.Background())
+	}()
+
+	log.Printf("listening on %s", *addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Print(err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+// ServeHTTP handles a single JSON-RPC 2.0 request. Requests must be POST requests with
+// a JSON body. Requests carrying an Origin header, which browsers send along with the
+// requests of web pages, are rejected, so that pages of other sites cannot make the
+// browsers of developers run the server through requests it cannot tell apart from
+// those of local clients.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.Header.Get("Origin") != "" {
+		http.Error(w, "requests from browsers are not supported", http.StatusForbidden)
+		return
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		http.Error(w, "the Content-Type of requests must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var req rpcRequest
+	resp := rpcResponse{JSONRPC: "2.0"}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp.Error = &rpcError{Code: rpcParseError, Message: err.Error()}
+	} else {
+		resp.ID = req.ID
+		resp.Result, resp.Error = s.call(req)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Print(err)
+	}
+}
+
+// call dispatches req to the method it names.
+func (s *server) call(req rpcRequest) (interface{}, *rpcError) {
+	if req.JSONRPC != "2.0" {
+		return nil, &rpcError{Code: rpcInvalidRequest, Message: "jsonrpc must be \"2.0\""}
+	}
+
+	var params rpcParams
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+	}
+
+	var args []string
+	switch req.Method {
+	case "lintPackage", "coverage":
+		if len(params.Patterns) == 0 {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: "patterns is required"}
+		}
+		args = params.Patterns
+	case "lintFile":
+		if params.File == "" {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: "file is required"}
+		}
+		args = []string{params.File}
+	default:
+		return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("method \"%s\" not found", req.Method)}
+	}
+
+	key := strings.Join(append([]string{req.Method, params.Dir}, args...), "\x00")
+	if result, ok := s.lookup(key); ok {
+		return result, nil
+	}
+
+	// The files are stamped before the packages are loaded, so that a change made while
+	// they are loaded invalidates the result computed from them.
+	stamps := stampArgs(params.Dir, args)
+	s.reloadConfig(stamps)
+
+	s.linterMu.RLock()
+	defer s.linterMu.RUnlock()
+
+	pkgs, files, err := load(params.Dir, args)
+	if err != nil {
+		return nil, &rpcError{Code: rpcInternalError, Message: err.Error()}
+	}
+
+	var errs []string
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			errs = append(errs, err.Error())
+		}
+	})
+
+	var result interface{}
+	if req.Method == "coverage" {
+		result = coverageResult{Coverage: measureCoverage(pkgs), Errors: errs}
+	} else {
+		issues, err := analyze(pkgs, files)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+
+		if issues == nil {
+			issues = []issue{}
+		}
+		result = lintResult{Issues: issues, Errors: errs}
+	}
+
+	s.store(key, result, stamps)
+	return result, nil
+}
+
+// reloadConfig makes the linter reload its configuration if the -config file, whose
+// state is found in stamps, has changed since it was last loaded. It waits for the
+// requests analyzing packages with the previous configuration to complete.
+func (s *server) reloadConfig(stamps map[string]stamp) {
+	path := configPath()
+	if path == "" {
+		return
+	}
+
+	s.mu.Lock()
+	st := stamps[path]
+	reload := st != s.config
+	s.config = st
+	s.mu.Unlock()
+
+	if reload {
+		s.linterMu.Lock()
+		linter.Reload()
+		s.linterMu.Unlock()
+	}
+}
+
+// configPath returns the path of the -config file of the linter, empty if there is none.
+func configPath() string {
+	return linter.Analyzer.Flags.Lookup("config").Value.String()
+}
+
+// lookup returns the cached result for key, if there is one and none of the files and
+// directories it was computed from have changed since.
+func (s *server) lookup(key string) (interface{}, bool) {
+	s.mu.Lock()
+	c, ok := s.cache[key]
+	s.mu.Unlock()
+
+	if !ok || changed(c.stamps) {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	c.used = time.Now()
+	s.mu.Unlock()
+
+	return c.result, true
+}
+
+// store caches result for key along with stamps, the state of the files and directories
+// it was computed from, evicting the least recently used result if the cache is full.
+// The result is not cached if stamps is nil.
+func (s *server) store(key string, result interface{}, stamps map[string]stamp) {
+	if stamps == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.cache[key]; !ok && len(s.cache) >= maxCachedResults {
+		var oldest string
+		for k, c := range s.cache {
+			if oldest == "" || c.used.Before(s.cache[oldest].used) {
+				oldest = k
+			}
+		}
+		delete(s.cache, oldest)
+	}
+
+	s.cache[key] = &cached{result: result, stamps: stamps, used: time.Now()}
+}
+
+// stampArgs returns the state of the files and directories of the packages matching args,
+// relative to dir, and of the packages they import, along with the state of the -config
+// file. It returns nil if the packages cannot be listed.
+func stampArgs(dir string, args []string) map[string]stamp {
+	patterns, _, err := resolveArgs(dir, args)
+	if err != nil {
+		return nil
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:  stampLoadMode,
+		Dir:   dir,
+		Tests: *includeTests,
+	}, patterns...)
+	if err != nil {
+		return nil
+	}
+	stamps := stampPackages(pkgs)
+
+	// A missing -config file is stamped too, with its zero state, so that creating it
+	// is detected.
+	if path := configPath(); path != "" {
+		stamps[path], _ = stampOf(path)
+	}
+
+	return stamps
+}
+
+// stampPackages returns the state of the files and directories of the local packages of
+// pkgs, keyed by path. Directories are included so that adding or removing a file is
+// detected.
+func stampPackages(pkgs []*packages.Package) map[string]stamp {
+	stamps := make(map[string]stamp)
+
+	for _, pkg := range localPackages(pkgs) {
+		for _, list := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles, pkg.OtherFiles} {
+			for _, path := range list {
+				for _, p := range []string{path, filepath.Dir(path)} {
+					if _, ok := stamps[p]; ok {
+						continue
+					}
+
+					if st, err := stampOf(p); err == nil {
+						stamps[p] = st
+					}
+				}
+			}
+		}
+	}
+
+	return stamps
+}
+
+// localPackages returns pkgs and the packages they import, directly or not, since the
+// findings of a package depend on the documentation of its imports. The imports from
+// the standard library and from the modules of the module cache, which do not change,
+// are left out.
+func localPackages(pkgs []*packages.Package) []*packages.Package {
+	roots := make(map[*packages.Package]bool, len(pkgs))
+	for _, pkg := range pkgs {
+		roots[pkg] = true
+	}
+
+	var local []*packages.Package
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if roots[pkg] || (pkg.Module != nil && (pkg.Module.Main || pkg.Module.Replace != nil)) {
+			local = append(local, pkg)
+		}
+	})
+
+	return local
+}
+
+// changed reports whether any of the files and directories of stamps has changed since
+// they were stamped.
+func changed(stamps map[string]stamp) bool {
+	for path, st := range stamps {
+		if current, err := stampOf(path); err != nil || current != st {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stampOf returns the current state of the file or directory at path.
+func stampOf(path string) (stamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return stamp{}, err
+	}
+
+	return stamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
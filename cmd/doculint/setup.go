@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// editors maps the editors supported by setup to the function writing their
+// configuration, given the path of the doculint binary and the analyzer flags to run
+// it with.
+var editors = map[string]func(w io.Writer, binary string, flags []string){
+	"vscode": writeVSCodeSetup,
+	"goland": writeGoLandSetup,
+	"vim":    writeVimSetup,
+}
+
+// setup writes ready to use configuration for the editor named in args to stdout, wired
+// to the currently running doculint binary and the analyzer flags given in args. It
+// returns the exit code the command should terminate with.
+func setup(args []string) int {
+	fs := flag.NewFlagSet("setup", flag.ExitOnError)
+	registerAnalyzerFlags(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: doculint setup [-flag] vscode|goland|vim\n\nWrites editor configuration running doculint with the given flags.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 || editors[fs.Arg(0)] == nil {
+		fs.Usage()
+		return exitError
+	}
+
+	binary, err := executable()
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+
+	flags, err := setFlags(fs)
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+
+	editors[fs.Arg(0)](os.Stdout, binary, flags)
+	return exitOK
+}
+
+// executable returns the path of the running doculint binary, with symbolic links
+// resolved.
+func executable() (string, error) {
+	binary, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.EvalSymlinks(binary)
+}
+
+// setFlags returns the flags set on fs as -name=value arguments. The path given to
+// -config is made absolute, since editors and hooks run tools from varying directories.
+func setFlags(fs *flag.FlagSet) ([]string, error) {
+	var flags []string
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		value := f.Value.String()
+
+		if f.Name == "config" && value != "" {
+			var abs string
+			if abs, err = filepath.Abs(value); err == nil {
+				value = abs
+			}
+		}
+
+		flags = append(flags, fmt.Sprintf("-%s=%s", f.Name, value))
+	})
+
+	return flags, err
+}
+
+// writeVSCodeSetup writes the settings and tasks for Visual Studio Code, which run
+// doculint through go vet when saving files and as a task with a problem matcher.
+func writeVSCodeSetup(w io.Writer, binary string, flags []string) {
+	vetFlags := []string{"-vettool=" + binary}
+	for _, f := range flags {
+		// go vet passes analyzer flags prefixed with the name of the analyzer.
+		vetFlags = append(vetFlags, "-doculint."+strings.TrimPrefix(f, "-"))
+	}
+
+	fmt.Fprintf(w, `// .vscode/settings.json
+{
+	"go.vetOnSave": "package",
+	"go.vetFlags": %s
+}
+
+// .vscode/tasks.json
+{
+	"version": "2.0.0",
+	"tasks": [
+		{
+			"label": "doculint",
+			"type": "shell",
+			"command": %s,
+			"args": %s,
+			"problemMatcher": {
+				"owner": "doculint",
+				"fileLocation": "absolute",
+				"pattern": {
+					"regexp": "^(.+?):(\\d+):(\\d+): (.*)$",
+					"file": 1,
+					"line": 2,
+					"column": 3,
+					"message": 4
+				}
+			}
+		}
+	]
+}
+`, jsonStrings(vetFlags), jsonString(binary), jsonStrings(append(flags, "./...")))
+}
+
+// writeGoLandSetup writes a file watcher for GoLand, which runs doculint on every Go
+// file when it is saved and highlights the findings.
+func writeGoLandSetup(w io.Writer, binary string, flags []string) {
+	fmt.Fprintf(w, `<!-- .idea/watcherTasks.xml -->
+<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+  <component name="ProjectTasksOptions">
+    <TaskOptions isEnabled="true">
+      <option name="arguments" value="%s $FilePath$" />
+      <option name="checkSyntaxErrors" value="true" />
+      <option name="description" value="Runs doculint on the saved file" />
+      <option name="exitCodeBehavior" value="ERROR" />
+      <option name="fileExtension" value="go" />
+      <option name="immediateSync" value="false" />
+      <option name="name" value="doculint" />
+      <option name="output" value="" />
+      <option name="outputFilters">
+        <array>
+          <FilterInfo>
+            <option name="name" value="doculint" />
+            <option name="description" value="" />
+            <option name="regExp" value="$FILE_PATH$:$LINE$:$COLUMN$: $MESSAGE$" />
+          </FilterInfo>
+        </array>
+      </option>
+      <option name="outputFromStdout" value="false" />
+      <option name="program" value="%s" />
+      <option name="runOnExternalChanges" value="false" />
+      <option name="scopeName" value="Project Files" />
+      <option name="trackOnlyRoot" value="false" />
+      <option name="workingDir" value="$FileDir$" />
+    </TaskOptions>
+  </component>
+</project>
+`, xmlEscape(strings.Join(flags, " ")), xmlEscape(binary))
+}
+
+// writeVimSetup writes Vim configuration that runs doculint through :make and loads the
+// findings into the quickfix list.
+func writeVimSetup(w io.Writer, binary string, flags []string) {
+	makeprg := strings.Join(append(append([]string{binary}, flags...), "./..."), " ")
+
+	fmt.Fprintf(w, `" Add to your vimrc, or to .vim/ftplugin/go.vim, then run :make to lint.
+let &l:makeprg = '%s'
+setlocal errorformat=%%f:%%l:%%c:\ %%m
+`, strings.ReplaceAll(makeprg, "'", "''"))
+}
+
+// jsonString formats s as a JSON string.
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// jsonStrings formats ss as a JSON array of strings.
+func jsonStrings(ss []string) string {
+	quoted := make([]string, len(ss))
+	for i := range ss {
+		quoted[i] = jsonString(ss[i])
+	}
+
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// xmlEscape escapes s for use within an XML attribute value.
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;").Replace(s)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"go/token"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/george-e-shaw-iv/doculint/docs"
+	"github.com/george-e-shaw-iv/doculint/internal/doculint"
+)
+
+// sonarEffortMinutes is the estimated time to fix a documentation issue, reported to
+// SonarQube as the technical debt of every issue.
+const sonarEffortMinutes = 5
+
+// sonarReport is a SonarQube generic external issues report, see
+// https://docs.sonarsource.com/sonarqube-server/latest/analyzing-source-code/importing-external-issues/generic-issue-import-format/.
+type sonarReport struct {
+	// Rules are the rules that reported the issues.
+	Rules []sonarRule `json:"rules"`
+
+	// Issues are the reported issues.
+	Issues []sonarIssue `json:"issues"`
+}
+
+// sonarRule describes a rule that reported issues.
+type sonarRule struct {
+	// ID is the ID of the rule, such as DL004.
+	ID string `json:"id"`
+
+	// Name is the name of the rule.
+	Name string `json:"name"`
+
+	// Description is the explanation of the convention checked by the rule.
+	Description string `json:"description"`
+
+	// EngineID is always "doculint".
+	EngineID string `json:"engineId"`
+
+	// CleanCodeAttribute is always CONVENTIONAL, since the rules check documentation
+	// conventions.
+	CleanCodeAttribute string `json:"cleanCodeAttribute"`
+
+	// Impacts are the qualities affected by the issues of the rule.
+	Impacts []sonarImpact `json:"impacts"`
+}
+
+// sonarImpact is the effect of the issues of a rule on a software quality.
+type sonarImpact struct {
+	// SoftwareQuality is always MAINTAINABILITY.
+	SoftwareQuality string `json:"softwareQuality"`
+
+	// Severity is MEDIUM for high confidence rules, LOW otherwise.
+	Severity string `json:"severity"`
+}
+
+// sonarIssue is an issue in SonarQube form.
+type sonarIssue struct {
+	// RuleID is the ID of the rule that reported the issue.
+	RuleID string `json:"ruleId"`
+
+	// EffortMinutes is the estimated time to fix the issue.
+	EffortMinutes int `json:"effortMinutes"`
+
+	// PrimaryLocation is where the issue was found.
+	PrimaryLocation sonarLocation `json:"primaryLocation"`
+
+	// SecondaryLocations are the other locations involved in the issue.
+	SecondaryLocations []sonarLocation `json:"secondaryLocations,omitempty"`
+}
+
+// sonarLocation is a range of a file.
+type sonarLocation struct {
+	// Message describes the location.
+	Message string `json:"message"`
+
+	// FilePath is the path of the file, relative to the working directory when
+	// possible.
+	FilePath string `json:"filePath"`
+
+	// TextRange is the range of the file.
+	TextRange sonarRange `json:"textRange"`
+}
+
+// sonarRange is a range of a file, with one-based lines and zero-based character
+// offsets, end exclusive. The columns are omitted when the content of the file cannot
+// be read.
+type sonarRange struct {
+	// StartLine and StartColumn are the start of the range.
+	StartLine   int  `json:"startLine"`
+	StartColumn *int `json:"startColumn,omitempty"`
+
+	// EndLine and EndColumn are the end of the range.
+	EndLine   int  `json:"endLine"`
+	EndColumn *int `json:"endColumn,omitempty"`
+}
+
+// writeSonar writes issues to w as a SonarQube generic external issues report, to be
+// imported with the sonar.externalIssuesReportPaths analysis parameter. Paths are
+// relative to the working directory, which should be the base directory of the
+// SonarQube project. Issues without a position are skipped, since SonarQube attaches
+// every issue to a file.
+func writeSonar(w io.Writer, issues []issue) error {
+	report := sonarReport{Rules: []sonarRule{}, Issues: []sonarIssue{}}
+
+	reported := make(map[string]bool)
+	for i := range issues {
+		is := &issues[i]
+		if !is.position.IsValid() {
+			continue
+		}
+
+		if !reported[is.Rule] {
+			reported[is.Rule] = true
+			report.Rules = append(report.Rules, sonarRuleOf(is))
+		}
+
+		si := sonarIssue{
+			RuleID:          is.Rule,
+			EffortMinutes:   sonarEffortMinutes,
+			PrimaryLocation: sonarLocationOf(is.Message, is.position, is.end),
+		}
+
+		for _, rel := range is.Related {
+			if rel.position.IsValid() {
+				si.SecondaryLocations = append(si.SecondaryLocations, sonarLocationOf(rel.Message, rel.position, rel.end))
+			}
+		}
+
+		report.Issues = append(report.Issues, si)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(report)
+}
+
+// sonarRuleOf returns the description of the rule that reported is, explained by the
+// first paragraph of its documentation.
+func sonarRuleOf(is *issue) sonarRule {
+	rule := sonarRule{
+		ID:                 is.Rule,
+		Name:               is.Rule,
+		Description:        is.URL,
+		EngineID:           "doculint",
+		CleanCodeAttribute: "CONVENTIONAL",
+		Impacts:            []sonarImpact{{SoftwareQuality: "MAINTAINABILITY", Severity: "LOW"}},
+	}
+
+	if is.Confidence == doculint.ConfidenceHigh.String() {
+		rule.Impacts[0].Severity = "MEDIUM"
+	}
+
+	if r, ok := linter.LookupRule(is.Rule); ok {
+		rule.Name = r.ID + " " + r.Name
+	}
+
+	// The documentation of a rule starts with its confidence, then explains it.
+	if text, ok := docs.Explain(is.Rule); ok {
+		if paragraphs := strings.Split(text, "\n\n"); len(paragraphs) > 1 {
+			rule.Description = strings.ReplaceAll(paragraphs[1], "\n", " ")
+		}
+	}
+
+	return rule
+}
+
+// sonarLocationOf returns the location spanning from start to end, which may be invalid
+// for issues reported at a single position, described by message.
+func sonarLocationOf(message string, start, end token.Position) sonarLocation {
+	loc := sonarLocation{
+		Message:   message,
+		FilePath:  relativePath(start.Filename),
+		TextRange: sonarRange{StartLine: start.Line, EndLine: start.Line},
+	}
+
+	// SonarQube counts characters rather than bytes, which takes the content of the file
+	// to convert columns.
+	content, err := os.ReadFile(start.Filename)
+	if err != nil {
+		return loc
+	}
+
+	line, column := characterPosition(content, start.Offset)
+	loc.TextRange.StartLine, loc.TextRange.StartColumn = line, &column
+
+	if end.IsValid() && end.Filename == start.Filename && end.Offset > start.Offset {
+		line, column := characterPosition(content, end.Offset)
+		loc.TextRange.EndLine, loc.TextRange.EndColumn = line, &column
+	} else {
+		loc.TextRange.EndLine = line
+	}
+
+	return loc
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// overlay maps the absolute paths of files to the content they are analyzed with in
+// place of their content on disk, which is their content in the index with -staged.
+var overlay map[string][]byte
+
+// stagedFiles returns the absolute paths of the Go files added, copied, modified, or
+// renamed in the index of the git repository containing the working directory, along
+// with their content in the index, keyed by path, which may differ from the content of
+// the files in the working tree when only some of their changes are staged.
+func stagedFiles() ([]string, map[string][]byte, error) {
+	root, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, nil, err
+	}
+	root = strings.TrimSpace(root)
+
+	out, err := git("diff", "--cached", "--name-only", "-z", "--diff-filter=ACMR", "--", "*.go")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var files []string
+	contents := make(map[string][]byte)
+	for _, name := range strings.Split(out, "\x00") {
+		if name == "" {
+			continue
+		}
+
+		// Paths in the index are relative to the root of the repository, as are the
+		// paths following a colon given to git show.
+		content, err := git("show", ":"+name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		path := filepath.Join(root, filepath.FromSlash(name))
+		files = append(files, path)
+		contents[path] = []byte(content)
+	}
+
+	return files, contents, nil
+}
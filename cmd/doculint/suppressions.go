@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"sort"
+
+	"github.com/george-e-shaw-iv/doculint/internal/doculint"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// scopeNames maps the scopes of suppressions to their capitalized names.
+var scopeNames = map[string]string{
+	doculint.ScopeFile:    "File",
+	doculint.ScopePackage: "Package",
+}
+
+// suppression is a //nolint comment suppressing the issues of a file or package,
+// resolved to a file position.
+type suppression struct {
+	// Scope is the scope of the issues suppressed, either "file" or "package".
+	Scope string
+
+	// Suppressed is the number of issues that were suppressed.
+	Suppressed int
+
+	// position is the resolved position of the comment.
+	position token.Position
+}
+
+// collectSuppressions gathers the suppressions found in the root packages of graph
+// that are kept by files, ordered by position. Suppressions found in both a package
+// and its test variant are only returned once, with the larger count of suppressed
+// issues since the test variant also covers test files.
+func collectSuppressions(graph *checker.Graph, files fileFilter) []suppression {
+	index := make(map[token.Position]int)
+
+	var suppressions []suppression
+	for _, act := range graph.Roots {
+		result, ok := act.Result.(*doculint.Result)
+		if act.Err != nil || !ok {
+			continue
+		}
+
+		for _, s := range result.Suppressions {
+			position := act.Package.Fset.Position(s.Pos)
+			if !files.keep(act.Package, position) {
+				continue
+			}
+
+			if i, seen := index[position]; seen {
+				suppressions[i].Suppressed = max(suppressions[i].Suppressed, s.Suppressed)
+				continue
+			}
+
+			index[position] = len(suppressions)
+			suppressions = append(suppressions, suppression{
+				Scope:      s.Scope,
+				Suppressed: s.Suppressed,
+				position:   position,
+			})
+		}
+	}
+
+	sort.Slice(suppressions, func(i, j int) bool {
+		a, b := suppressions[i].position, suppressions[j].position
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+
+	return suppressions
+}
+
+// writeSuppressions writes a report of suppressions to w, one suppression per line,
+// so that the files and packages excluded from the analysis are not forgotten.
+func writeSuppressions(w io.Writer, suppressions []suppression) {
+	if len(suppressions) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\ndoculint found %d suppressions, issues suppressed by each:\n", len(suppressions))
+	for _, s := range suppressions {
+		fmt.Fprintf(w, "  - %s suppression: %d (%s)\n", scopeNames[s.Scope], s.Suppressed, s.position)
+	}
+}
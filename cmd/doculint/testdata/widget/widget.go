@@ -0,0 +1,16 @@
+// Package widget holds the widgets whose issues are written in every output format.
+package widget
+
+// Draws the widget.
+func Render() string { return "" }
+
+func Paint() {}
+
+// retry reports whether a request failed retries times should be retried.
+func retry(retries int) bool {
+	if retries > 3 {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/tools/go/packages"
+)
+
+// watched is the state of the packages of a directory analyzed in watch mode.
+type watched struct {
+	// issues are the issues found in the packages of the directory.
+	issues []issue
+
+	// deps are the directories of the packages of the directory and of the local
+	// packages they import, whose changes change the issues of the directory.
+	deps map[string]bool
+}
+
+// watch loads the packages matching args and analyzes them, then watches the
+// directories of their files and reanalyzes the packages of the directories whose files
+// changed, until it is interrupted. Changes are collected for interval before the
+// packages are reanalyzed, since saving a file commonly takes several events. The
+// packages matching args are listed again when directories or Go files are added or
+// removed, so that packages added after watch mode started are analyzed, and removed
+// ones are forgotten. The issues of every package are printed to w after each analysis,
+// followed by a summary. It returns the exit code the command should terminate with.
+func watch(w io.Writer, args []string, interval time.Duration) int {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+	defer watcher.Close()
+
+	// The directories are watched before the packages are loaded, so that a change made
+	// while they are loaded is not missed.
+	deps, err := listDeps(watcher, "", args)
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+
+	pkgs, files, err := load("", args)
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+
+	// Packages are tracked by directory, since a directory is reloaded as a whole along
+	// with the test variants of its package.
+	dirs := make(map[string]*watched)
+	for dir, group := range groupByDir(pkgs) {
+		dirs[dir] = analyzeDir(dir, group, files, deps[dir])
+	}
+	writeWatchSummary(w, dirs)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// changes are the paths changed since the last analysis and the directories holding
+	// them, and relist is set when the packages matching args may have changed.
+	changes := make(map[string]bool)
+	relist := false
+	var batch <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return exitOK
+		case err := <-watcher.Errors:
+			log.Print(err)
+			continue
+		case event := <-watcher.Events:
+			if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) && !event.Has(fsnotify.Remove) && !event.Has(fsnotify.Rename) {
+				continue
+			}
+
+			changes[filepath.Dir(event.Name)] = true
+			changes[event.Name] = true
+			if addsOrRemovesPackage(watcher, event, dirs) {
+				relist = true
+			}
+
+			if batch == nil {
+				batch = time.After(interval)
+			}
+			continue
+		case <-batch:
+			batch = nil
+		}
+
+		reanalyzed := false
+		if relist {
+			relist = false
+
+			if current, err := listDeps(watcher, "", args); err != nil {
+				log.Print(err)
+			} else {
+				for dir := range dirs {
+					if current[dir] == nil {
+						delete(dirs, dir)
+						reanalyzed = true
+					}
+				}
+
+				// The new directories are marked as changed, which analyzes them below.
+				for dir := range current {
+					if dirs[dir] == nil {
+						dirs[dir] = &watched{deps: map[string]bool{dir: true}}
+						changes[dir] = true
+					}
+				}
+			}
+		}
+
+		for dir, state := range dirs {
+			if !dependsOn(state, changes) {
+				continue
+			}
+			reanalyzed = true
+
+			deps, err := listDeps(watcher, dir, []string{"."})
+			if err != nil {
+				// The directory may be mid-edit or removed, it is retried on the next
+				// change.
+				log.Print(err)
+				continue
+			}
+
+			pkgs, _, err := load(dir, []string{"."})
+			if err != nil {
+				log.Print(err)
+				continue
+			}
+
+			dirs[dir] = analyzeDir(dir, pkgs, files, deps[dir])
+		}
+		clear(changes)
+
+		if reanalyzed {
+			writeWatchSummary(w, dirs)
+		}
+	}
+}
+
+// listDeps lists the packages matching args, relative to dir, and watches the
+// directories of their local packages with watcher, along with the parents of their
+// directories, where packages are added. It returns the directories of the local
+// packages of each package, keyed by the directory of the package.
+func listDeps(watcher *fsnotify.Watcher, dir string, args []string) (map[string]map[string]bool, error) {
+	patterns, _, err := resolveArgs(dir, args)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:  stampLoadMode,
+		Dir:   dir,
+		Tests: *includeTests,
+	}, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make(map[string]map[string]bool)
+	for _, pkg := range pkgs {
+		if pkg.Dir == "" {
+			continue
+		}
+
+		if deps[pkg.Dir] == nil {
+			deps[pkg.Dir] = map[string]bool{pkg.Dir: true}
+		}
+		for _, local := range localPackages([]*packages.Package{pkg}) {
+			if local.Dir != "" {
+				deps[pkg.Dir][local.Dir] = true
+			}
+		}
+	}
+
+	for root, set := range deps {
+		for d := range set {
+			if err := watcher.Add(d); err != nil {
+				log.Print(err)
+			}
+		}
+
+		// Watching the parent of a package is best effort, it may be outside of the
+		// module or unreadable.
+		_ = watcher.Add(filepath.Dir(root))
+	}
+
+	return deps, nil
+}
+
+// addsOrRemovesPackage reports whether event may add or remove a package: a directory or
+// a Go file in a directory without packages is created, in which case the directory is
+// watched for its files, or the directory of a package is removed or renamed.
+func addsOrRemovesPackage(watcher *fsnotify.Watcher, event fsnotify.Event, dirs map[string]*watched) bool {
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			_ = watcher.Add(event.Name)
+			return true
+		}
+
+		return strings.HasSuffix(event.Name, ".go") && dirs[filepath.Dir(event.Name)] == nil
+	}
+
+	return (event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename)) && dirs[event.Name] != nil
+}
+
+// dependsOn reports whether the issues of state depend on one of the changed paths.
+func dependsOn(state *watched, changes map[string]bool) bool {
+	for dir := range state.deps {
+		if changes[dir] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// groupByDir groups pkgs by the directory holding their files.
+func groupByDir(pkgs []*packages.Package) map[string][]*packages.Package {
+	groups := make(map[string][]*packages.Package)
+	for _, pkg := range pkgs {
+		groups[pkg.Dir] = append(groups[pkg.Dir], pkg)
+	}
+
+	return groups
+}
+
+// analyzeDir analyzes pkgs, the packages of dir, and returns their issues kept by files
+// along with deps, the directories their issues depend on, which default to dir.
+func analyzeDir(dir string, pkgs []*packages.Package, files fileFilter, deps map[string]bool) *watched {
+	issues, err := analyze(pkgs, files)
+	if err != nil {
+		log.Print(err)
+	}
+
+	if deps == nil {
+		deps = map[string]bool{dir: true}
+	}
+
+	return &watched{issues: issues, deps: deps}
+}
+
+// writeWatchSummary writes the issues of every watched directory to w, ordered by
+// directory, followed by the number of issues and packages with issues.
+func writeWatchSummary(w io.Writer, dirs map[string]*watched) {
+	names := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		names = append(names, dir)
+	}
+	sort.Strings(names)
+
+	total, failing := 0, 0
+	for _, dir := range names {
+		issues := dirs[dir].issues
+		writeText(w, issues)
+
+		total += len(issues)
+		if len(issues) > 0 {
+			failing++
+		}
+	}
+
+	fmt.Fprintf(w, "doculint: %d issues in %d of %d directories at %s, watching for changes\n", total, failing, len(dirs), time.Now().Format(time.TimeOnly))
+}
@@ -0,0 +1,27 @@
+// Package docs holds the documentation of the rules of the doculint analyzer, which is
+// published as rules.md and printed by doculint -explain.
+package docs
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// rules is the content of rules.md, with a section per rule whose heading is the ID of
+// the rule followed by its name, such as "## DL004 function-comment".
+//
+//go:embed rules.md
+var rules string
+
+// Explain returns the section of rules.md documenting the rule with the given ID, such
+// as DL004, heading excluded, and whether the rule is documented.
+func Explain(id string) (string, bool) {
+	for _, section := range strings.Split(rules, "\n## ")[1:] {
+		heading, body, _ := strings.Cut(section, "\n")
+		if ruleID, _, _ := strings.Cut(heading, " "); ruleID == id {
+			return strings.TrimSpace(body), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,303 @@
+// Package doculint runs the doculint analyzer from other Go programs, which receive its
+// findings as structured issues rather than having to parse the output of the doculint
+// command. Each Linter runs an analyzer of its own, configured through its flags, which
+// are those of the doculint command.
+package doculint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"sort"
+	"sync"
+
+	"github.com/george-e-shaw-iv/doculint/internal/doculint"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// LoadMode is the mode the packages given to Run must at least be loaded with.
+const LoadMode = packages.LoadSyntax
+
+// Confidence describes how likely it is that an issue is a genuine documentation problem
+// rather than a false positive.
+type Confidence = doculint.Confidence
+
+// Confidence levels, ordered from least to most confident.
+const (
+	// ConfidenceLow is used by heuristics that are noisy but still useful to surface.
+	ConfidenceLow = doculint.ConfidenceLow
+
+	// ConfidenceMedium is used by heuristics that are usually, but not always, right.
+	ConfidenceMedium = doculint.ConfidenceMedium
+
+	// ConfidenceHigh is used by rules that are mechanical checks of a convention.
+	ConfidenceHigh = doculint.ConfidenceHigh
+)
+
+// Severity is how an issue should be presented, derived from the confidence of the rule
+// that reported it, as the output formats of the doculint command do.
+type Severity int
+
+// Severity levels, ordered from least to most severe.
+const (
+	// SeverityInfo is the severity of the issues of medium and low confidence rules.
+	SeverityInfo Severity = iota
+
+	// SeverityWarning is the severity of the issues of high confidence rules.
+	SeverityWarning
+)
+
+// String returns the name of the severity, info or warning.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	}
+
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Issue is a finding reported by the analyzer.
+type Issue struct {
+	// Rule is the ID of the rule that reported the issue, such as DL004.
+	Rule string
+
+	// Confidence is the confidence of the rule that reported the issue.
+	Confidence Confidence
+
+	// Severity is SeverityWarning for the issues of high confidence rules,
+	// SeverityInfo otherwise.
+	Severity Severity
+
+	// Pos is the position of the issue.
+	Pos token.Position
+
+	// End is the end of the range of the issue, if the rule reported one, such as the
+	// identifier or comment in question. It is invalid otherwise.
+	End token.Position
+
+	// Message is the human readable description of the issue.
+	Message string
+
+	// URL is the URL of the documentation of the rule that reported the issue.
+	URL string
+
+	// Fixes are the fixes suggested for the issue, if any. They are alternatives, only
+	// one of them should be applied.
+	Fixes []Fix
+}
+
+// Fix is a suggested fix of an issue.
+type Fix struct {
+	// Message describes the fix.
+	Message string
+
+	// Edits are the edits making up the fix, which do not overlap.
+	Edits []Edit
+}
+
+// Edit replaces the text of a file between two positions.
+type Edit struct {
+	// Pos and End are the start and end of the text replaced, which are the same for
+	// insertions.
+	Pos, End token.Position
+
+	// NewText is the replacement text.
+	NewText string
+}
+
+// Linter runs an analyzer of its own, whose settings are shared with no other analyzer
+// of the process, such as that of the analyzer package.
+type Linter struct {
+	// Analyzer is the analyzer run by the linter, configured through its flags, such as
+	// Analyzer.Flags.Set("period", "all"), before the linter first runs.
+	Analyzer *analysis.Analyzer
+
+	// linter holds the settings of Analyzer.
+	linter *doculint.Linter
+}
+
+// New returns a linter running an analyzer with the default settings.
+func New() *Linter {
+	l := doculint.New()
+	return &Linter{Analyzer: l.Analyzer, linter: l}
+}
+
+// Lint analyzes pkgs, loaded with at least LoadMode, with a linter with the default
+// settings. See Linter.Lint.
+func Lint(pkgs []*packages.Package) ([]Issue, error) {
+	return New().Lint(pkgs)
+}
+
+// Run analyzes pkgs, loaded with at least LoadMode, with a linter with the default
+// settings. See Linter.Run.
+func Run(ctx context.Context, pkgs []*packages.Package, fn func(Issue)) error {
+	return New().Run(ctx, pkgs, fn)
+}
+
+// Lint analyzes pkgs, loaded with at least LoadMode, and returns the issues found
+// ordered by file and position, then by rule and message. The analysis errors of the
+// packages are joined and returned alongside the issues that could be found.
+func (l *Linter) Lint(pkgs []*packages.Package) ([]Issue, error) {
+	var issues []Issue
+	err := l.Run(context.Background(), pkgs, func(is Issue) {
+		issues = append(issues, is)
+	})
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Pos.Filename != b.Pos.Filename {
+			return a.Pos.Filename < b.Pos.Filename
+		}
+		if a.Pos.Offset != b.Pos.Offset {
+			return a.Pos.Offset < b.Pos.Offset
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Message < b.Message
+	})
+
+	return issues, err
+}
+
+// Run analyzes pkgs, loaded with at least LoadMode, and calls fn with every issue found
+// as soon as the package it belongs to is analyzed, so that callers can render issues
+// before the analysis of large sets of packages completes. Packages are analyzed
+// concurrently, but fn is never called concurrently. The issues of a package are passed
+// in order of position, while packages complete in no particular order. Issues found in
+// both a package and its test variant are only passed once.
+//
+// Run stops analyzing packages when ctx is done, returning its error. Otherwise, the
+// analysis errors of the packages are joined and returned once every package has been
+// analyzed.
+func (l *Linter) Run(ctx context.Context, pkgs []*packages.Package, fn func(Issue)) error {
+	// key identifies an issue, which is reported by both a package and its test variant
+	// for the files they share.
+	type key struct {
+		pos     token.Position
+		message string
+	}
+
+	var (
+		mu   sync.Mutex
+		seen = make(map[key]bool)
+	)
+
+	roots := make(map[*types.Package]bool, len(pkgs))
+	for _, pkg := range pkgs {
+		roots[pkg.Types] = true
+	}
+
+	// The analyzer runs on the dependencies of pkgs too, for the facts they export, so
+	// the issues of pkgs are passed to fn from a copy of it as each package completes,
+	// rather than once the whole graph is analyzed.
+	analyzer := *l.Analyzer
+	analyzer.Run = func(pass *analysis.Pass) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var diags []analysis.Diagnostic
+		pass.Report = func(diag analysis.Diagnostic) {
+			diags = append(diags, diag)
+		}
+
+		result, err := l.Analyzer.Run(pass)
+		if err != nil || !roots[pass.Pkg] {
+			return result, err
+		}
+
+		issues := make([]Issue, 0, len(diags))
+		for _, diag := range diags {
+			issues = append(issues, l.issueOf(pass.Fset, diag))
+		}
+
+		sort.SliceStable(issues, func(i, j int) bool {
+			a, b := issues[i].Pos, issues[j].Pos
+			if a.Filename != b.Filename {
+				return a.Filename < b.Filename
+			}
+			return a.Offset < b.Offset
+		})
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, is := range issues {
+			k := key{is.Pos, is.Message}
+			if !seen[k] && ctx.Err() == nil {
+				seen[k] = true
+				fn(is)
+			}
+		}
+
+		return result, nil
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{&analyzer}, pkgs, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", act.Package.ID, act.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// issueOf converts diag, reported by the analyzer of l, to an issue.
+func (l *Linter) issueOf(fset *token.FileSet, diag analysis.Diagnostic) Issue {
+	is := Issue{
+		Rule:       diag.Category,
+		Confidence: ConfidenceHigh,
+		Pos:        fset.Position(diag.Pos),
+		Message:    diag.Message,
+		URL:        diag.URL,
+	}
+
+	if rule, ok := l.linter.LookupRule(diag.Category); ok {
+		is.Confidence = rule.Confidence
+	}
+
+	if is.Confidence == ConfidenceHigh {
+		is.Severity = SeverityWarning
+	}
+
+	if diag.End.IsValid() {
+		is.End = fset.Position(diag.End)
+	}
+
+	for _, sf := range diag.SuggestedFixes {
+		fix := Fix{Message: sf.Message}
+		for _, te := range sf.TextEdits {
+			end := te.End
+			if !end.IsValid() {
+				end = te.Pos
+			}
+
+			fix.Edits = append(fix.Edits, Edit{
+				Pos:     fset.Position(te.Pos),
+				End:     fset.Position(end),
+				NewText: string(te.NewText),
+			})
+		}
+		is.Fixes = append(is.Fixes, fix)
+	}
+
+	return is
+}
@@ -0,0 +1,94 @@
+package doculint
+
+import (
+	"go/ast"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// setterPrefix is the prefix of the names of setters.
+const setterPrefix = "Set"
+
+// accessorWords are the words, besides filler words and the words of the property and
+// its type, that the comment of a getter or setter only echoing its name is made of.
+var accessorWords = map[string]bool{
+	"get": true, "return": true, "set": true, "value": true, "current": true, "new": true,
+}
+
+// checkAccessors validates the comments of the getters and setters of the package
+// analyzed by pass, which are pairs of exported methods of the same type named Foo and
+// SetFoo, when -accessor-docs is set. The comments of both methods must do more than
+// echo their names, as in "Foo returns the foo" and "SetFoo sets the foo". With
+// -accessor-links, each comment must also mention the other method, so that readers of
+// either find both.
+func (l *Linter) checkAccessors(pass *analysis.Pass) {
+	if !l.requireAccessorDocs {
+		return
+	}
+
+	methods := make(map[string]map[string]*ast.FuncDecl)
+	var setters []*ast.FuncDecl
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() {
+				continue
+			}
+
+			receiver := receiverTypeName(fn.Recv)
+			if methods[receiver] == nil {
+				methods[receiver] = make(map[string]*ast.FuncDecl)
+			}
+			methods[receiver][fn.Name.Name] = fn
+
+			if strings.HasPrefix(fn.Name.Name, setterPrefix) && len(fn.Name.Name) > len(setterPrefix) {
+				setters = append(setters, fn)
+			}
+		}
+	}
+
+	for _, setter := range setters {
+		receiver := receiverTypeName(setter.Recv)
+		getter := methods[receiver][strings.TrimPrefix(setter.Name.Name, setterPrefix)]
+		if getter == nil {
+			continue
+		}
+
+		// Undocumented methods are already reported as such.
+		for _, pair := range [][2]*ast.FuncDecl{{getter, setter}, {setter, getter}} {
+			fn, other := pair[0], pair[1]
+			if fn.Doc == nil {
+				continue
+			}
+
+			if echoesAccessor(fn, getter.Name.Name, receiver) {
+				l.report(pass, RuleAccessorComment, fn.Pos(), "comment for method \"%s\" only echoes its name, describe what %s of %s means and how it is used", fn.Name.Name, getter.Name.Name, receiver)
+			} else if l.requireAccessorLinks && !containsWord(fn.Doc.Text(), other.Name.Name) {
+				l.report(pass, RuleAccessorComment, fn.Pos(), "comment for method \"%s\" should mention its counterpart \"%s\", as in \"see [%s.%s]\"", fn.Name.Name, other.Name.Name, receiver, other.Name.Name)
+			}
+		}
+	}
+}
+
+// echoesAccessor reports whether the comment of fn, the getter or setter of the property
+// with the given name of the receiver type, only consists of the name of fn followed by
+// filler words, accessor words, and the words of the property, the receiver, and the
+// signature of fn.
+func echoesAccessor(fn *ast.FuncDecl, property, receiver string) bool {
+	known := make(map[string]bool)
+	for _, word := range append(append(identifierWords(property), identifierWords(receiver)...), signatureWords(fn)...) {
+		known[stem(word)] = true
+	}
+
+	text := strings.TrimPrefix(strings.TrimSpace(fn.Doc.Text()), fn.Name.Name)
+	for _, field := range strings.FieldsFunc(text, isNotWordRune) {
+		for _, word := range identifierWords(field) {
+			if !fillerWords[word] && !accessorWords[word] && !accessorWords[stem(word)] && !known[stem(word)] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,66 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// largePackage returns the source of a package with n of each kind of declaration,
+// half of them documented, and functions comparing and switching on literals, standing
+// in for the large generated or legacy packages of monorepos.
+func largePackage(n int) string {
+	var b strings.Builder
+	b.WriteString("// Package p is a large package.\npackage p\n\n")
+	for i := 0; i < n; i++ {
+		if i%2 == 0 {
+			fmt.Fprintf(&b, "// F%d returns the %dth value, or zero if x is negative.\n", i, i)
+		}
+		fmt.Fprintf(&b, "func F%d(x int) int {\n\tif x < 0 {\n\t\treturn 0\n\t}\n\tswitch x {\n\tcase 42:\n\t\treturn %d\n\t}\n\treturn x + %d\n}\n\n", i, i, i)
+
+		if i%2 == 0 {
+			fmt.Fprintf(&b, "// T%d holds the %dth value.\n", i, i)
+		}
+		fmt.Fprintf(&b, "type T%d struct {\n\t// V is the value.\n\tV int\n}\n\n", i)
+
+		if i%2 == 0 {
+			fmt.Fprintf(&b, "// Get returns the value of t, the %dth one.\n", i)
+		}
+		fmt.Fprintf(&b, "func (t T%d) Get() int { return t.V }\n\n", i)
+
+		if i%2 == 0 {
+			fmt.Fprintf(&b, "// C%d is the %dth constant.\n", i, i)
+		}
+		fmt.Fprintf(&b, "const C%d = %d\n\n", i, i)
+	}
+
+	return b.String()
+}
+
+// BenchmarkAnalyzer measures the time and allocations of a pass of the analyzer with
+// its default flags over a large package, parsed and type checked once, as are the
+// results of inspect.Analyzer shared with other analyzers.
+func BenchmarkAnalyzer(b *testing.B) {
+	src := []byte(largePackage(1000))
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		b.Fatal(err)
+	}
+	pkg, info := checkFile(fset, file)
+	insp := inspector.New([]*ast.File{file})
+	l := New()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := runAnalyzer(l, fset, file, src, pkg, info, insp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// specGroup is a run of specs in a constant or variable block that is not interrupted
+// by a blank line.
+type specGroup struct {
+	// first is the first spec of the group.
+	first ast.Spec
+
+	// size is the number of specs in the group.
+	size int
+
+	// commented reports whether the group is introduced by a comment.
+	commented bool
+}
+
+// checkBlockGrouping reports the constant or variable block decl of file if it has more
+// than the given number of specs but is not split into groups separated by blank
+// lines, as well as the groups of such a block that are not introduced by a comment,
+// since godoc renders blocks as they are written. A threshold of 0 disables the check.
+func (l *Linter) checkBlockGrouping(pass *analysis.Pass, file *ast.File, threshold int, decl *ast.GenDecl) {
+	if threshold <= 0 || !decl.Lparen.IsValid() || len(decl.Specs) <= threshold {
+		return
+	}
+
+	what := "constant block"
+	if decl.Tok == token.VAR {
+		what = "variable block"
+	}
+
+	groups := groupSpecs(pass.Fset, file, decl)
+	if len(groups) == 1 {
+		l.report(pass, RuleBlockGrouping, decl.Pos(), "%s has %d entries and should be split into groups separated by blank lines, each introduced by a comment", what, len(decl.Specs))
+		return
+	}
+
+	for _, group := range groups {
+		if !group.commented {
+			l.report(pass, RuleBlockGrouping, group.first.Pos(), "group of %d entries in %s should be introduced by a comment", group.size, what)
+		}
+	}
+}
+
+// groupSpecs splits the specs of the block decl of file into groups separated by blank
+// lines. A group is introduced by a comment if its first spec has a doc comment or a
+// comment is placed between the previous group and its first spec.
+func groupSpecs(fset *token.FileSet, file *ast.File, decl *ast.GenDecl) []specGroup {
+	// occupied are the lines of the block containing comments.
+	occupied := make(map[int]bool)
+	for _, cg := range file.Comments {
+		if cg.Pos() < decl.Lparen || cg.End() > decl.Rparen {
+			continue
+		}
+
+		for line := fset.Position(cg.Pos()).Line; line <= fset.Position(cg.End()).Line; line++ {
+			occupied[line] = true
+		}
+	}
+
+	var groups []specGroup
+	prev := decl.Lparen
+	for _, spec := range decl.Specs {
+		start := spec.Pos()
+		if doc := specDoc(spec); doc != nil {
+			start = doc.Pos()
+		}
+
+		blank := len(groups) == 0
+		for line := fset.Position(prev).Line + 1; line < fset.Position(start).Line; line++ {
+			if !occupied[line] {
+				blank = true
+				break
+			}
+		}
+
+		if blank {
+			groups = append(groups, specGroup{
+				first:     spec,
+				commented: start != spec.Pos() || commentBetween(file, prev, start),
+			})
+		}
+		groups[len(groups)-1].size++
+
+		prev = spec.End()
+		if vs, ok := spec.(*ast.ValueSpec); ok && vs.Comment != nil {
+			prev = vs.Comment.End()
+		}
+	}
+
+	return groups
+}
+
+// specDoc returns the doc comment of the given spec, or nil if it has none.
+func specDoc(spec ast.Spec) *ast.CommentGroup {
+	switch spec := spec.(type) {
+	case *ast.ValueSpec:
+		return spec.Doc
+	case *ast.TypeSpec:
+		return spec.Doc
+	case *ast.ImportSpec:
+		return spec.Doc
+	}
+
+	return nil
+}
+
+// commentBetween reports whether file has a comment placed between from and to.
+func commentBetween(file *ast.File, from, to token.Pos) bool {
+	for _, cg := range file.Comments {
+		if cg.Pos() > from && cg.End() < to {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,69 @@
+package doculint
+
+import (
+	"go/ast"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// exportDirective is the prefix of the cgo directives exporting a function to C.
+const exportDirective = "//export "
+
+// checkCgoExport validates the comment of the function fn if it is exported to C with
+// an //export directive, which forms a C visible API. The comment must have text above
+// the directive, which must come last so that go/doc and gofmt keep it apart from the
+// documentation. It returns true if the comment has no text, in
+// which case the regular checks of function comments do not apply.
+func (l *Linter) checkCgoExport(pass *analysis.Pass, fn *ast.FuncDecl) bool {
+	directive := -1
+	for i, c := range fn.Doc.List {
+		if strings.HasPrefix(c.Text, exportDirective) {
+			directive = i
+		}
+	}
+
+	if directive < 0 {
+		return false
+	}
+
+	if strings.TrimSpace(fn.Doc.Text()) == "" {
+		l.report(pass, RuleCgoExport, fn.Pos(), "function \"%s\" is exported to C and has no comment associated with it above its //export directive", fn.Name.Name)
+		return true
+	}
+
+	for _, c := range fn.Doc.List[directive+1:] {
+		if !isDirective(c.Text) {
+			l.report(pass, RuleCgoExport, fn.Doc.List[directive].Pos(), "//export directive of function \"%s\" should come after its comment", fn.Name.Name)
+			break
+		}
+	}
+
+	return false
+}
+
+// withoutCgoFiles returns a copy of pass without the files generated by cgo for the
+// definitions of the C package, which are not source files of the package. Files
+// rewritten by cgo are kept, as line directives map them to their source.
+func withoutCgoFiles(pass *analysis.Pass) *analysis.Pass {
+	files := make([]*ast.File, 0, len(pass.Files))
+	for _, file := range pass.Files {
+		if !isCgoGenerated(pass, file) {
+			files = append(files, file)
+		}
+	}
+
+	if len(files) == len(pass.Files) {
+		return pass
+	}
+
+	p := *pass
+	p.Files = files
+	return &p
+}
+
+// isCgoGenerated reports whether file was generated by cgo for the definitions of the C
+// package, whose line directives map it to names without a .go extension.
+func isCgoGenerated(pass *analysis.Pass, file *ast.File) bool {
+	return !strings.HasSuffix(pass.Fset.Position(file.Package).Filename, ".go")
+}
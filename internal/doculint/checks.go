@@ -0,0 +1,224 @@
+package doculint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"reflect"
+	"sort"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Check is a documentation check added to the analyzer by its users, such as an
+// organization specific convention. Checks run during the traversal of the files of
+// every package analyzed, and their issues go through the same suppressions,
+// -min-confidence filtering, and de-duplication as those of the rules of the analyzer.
+type Check interface {
+	// Rule returns the rule the issues of the check are reported under. Its ID is used
+	// in suppressions, such as //nolint:ORG001, and must not be the ID of another rule.
+	Rule() Rule
+
+	// Nodes returns a node of each type the check inspects, such as
+	// (*ast.FuncDecl)(nil). Run is only called with nodes of these types.
+	Nodes() []ast.Node
+
+	// Run checks node, found in a file of the package analyzed by pass, reporting its
+	// issues through report.
+	Run(pass *analysis.Pass, node ast.Node, report ReportFunc)
+}
+
+// ConfigurableCheck is a check taking settings from the configuration file given
+// through -config, found in its "checks" object under the ID of the rule of the check.
+type ConfigurableCheck interface {
+	Check
+
+	// Configure is called with the settings of the check before it first runs, if the
+	// configuration file has any.
+	Configure(settings json.RawMessage) error
+}
+
+// ReportFunc reports an issue spanning rng, such as the declaration or comment in
+// question, under the rule of the check it is given to.
+type ReportFunc func(rng analysis.Range, format string, args ...interface{})
+
+// registry holds the checks registered with RegisterCheck, ordered by the ID of their
+// rules, which every linter runs along with those of its configuration file.
+var registry struct {
+	mu     sync.RWMutex
+	checks []Check
+}
+
+// RegisterCheck adds c to the checks run by every linter. It is meant to be called from
+// init functions, before any package is analyzed, and panics if the ID of the rule of c
+// is empty or already used by another rule. The checks are shared by the linters of the
+// process, a ConfigurableCheck being configured by every linter whose configuration
+// file has settings for it.
+func RegisterCheck(c Check) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if err := validateCheck(c, registry.checks); err != nil {
+		panic("doculint: RegisterCheck: " + err.Error())
+	}
+
+	registry.checks = sortChecks(append(registry.checks, c))
+}
+
+// validateCheck returns an error if the ID of the rule of c is empty or already used by
+// a rule of the analyzer or of checks.
+func validateCheck(c Check, checks []Check) error {
+	rule := c.Rule()
+	if rule.ID == "" {
+		return errors.New("rule has no ID")
+	}
+
+	if _, ok := builtinRulesByID[rule.ID]; ok {
+		return fmt.Errorf("rule %s is already registered", rule.ID)
+	}
+	for _, other := range checks {
+		if other.Rule().ID == rule.ID {
+			return fmt.Errorf("rule %s is already registered", rule.ID)
+		}
+	}
+
+	return nil
+}
+
+// sortChecks sorts checks by the ID of their rules and returns them.
+func sortChecks(checks []Check) []Check {
+	sort.SliceStable(checks, func(i, j int) bool {
+		return checks[i].Rule().ID < checks[j].Rule().ID
+	})
+
+	return checks
+}
+
+// registeredChecks returns the checks registered with RegisterCheck, ordered by the ID
+// of their rules.
+func registeredChecks() []Check {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	return append([]Check(nil), registry.checks...)
+}
+
+// loadChecks returns the dispatcher of the checks run by l, which are the registered
+// checks and those defined by the rules of its configuration file, setting them up the
+// first time it is called.
+func (l *Linter) loadChecks() (*checkDispatcher, error) {
+	l.checks.once.Do(func() {
+		l.checks.list = registeredChecks()
+
+		cfg, err := l.loadConfig()
+		if err == nil {
+			err = l.setupChecks(cfg)
+		}
+		l.checks.err = err
+
+		l.checks.rules = make(map[string]Rule, len(l.checks.list))
+		for _, c := range l.checks.list {
+			l.checks.rules[c.Rule().ID] = c.Rule()
+		}
+		l.checks.dispatcher = newCheckDispatcher(l, l.checks.list)
+	})
+
+	return l.checks.dispatcher, l.checks.err
+}
+
+// setupChecks adds the checks defined by the rules of cfg to those of l, and passes the
+// settings of cfg to the checks taking some.
+func (l *Linter) setupChecks(cfg config) error {
+	checks := l.checks.list
+	for _, sr := range cfg.Rules {
+		c, err := compileScriptRule(sr)
+		if err == nil {
+			err = validateCheck(c, checks)
+		}
+		if err != nil {
+			return fmt.Errorf("config %s: %w", l.configPath, err)
+		}
+
+		checks = append(checks, c)
+	}
+	l.checks.list = sortChecks(checks)
+
+	for _, c := range l.checks.list {
+		cc, ok := c.(ConfigurableCheck)
+		if !ok {
+			continue
+		}
+
+		settings, ok := cfg.Checks[c.Rule().ID]
+		if !ok {
+			continue
+		}
+
+		if err := cc.Configure(settings); err != nil {
+			return fmt.Errorf("configure check %s: %w", c.Rule().ID, err)
+		}
+	}
+
+	return nil
+}
+
+// checkDispatcher maps the types of nodes to the checks of a linter inspecting them.
+type checkDispatcher struct {
+	// linter is the linter the issues of the checks are reported through.
+	linter *Linter
+
+	// checks are the checks, keyed by the types of the nodes they inspect.
+	checks map[reflect.Type][]Check
+
+	// filter is the node filter of the traversal of the files of a package, nodeFilter
+	// along with a node of each type the checks inspect.
+	filter []ast.Node
+}
+
+// newCheckDispatcher returns the dispatcher of checks, reporting their issues through l.
+func newCheckDispatcher(l *Linter, checks []Check) *checkDispatcher {
+	d := &checkDispatcher{
+		linter: l,
+		checks: make(map[reflect.Type][]Check),
+		filter: append([]ast.Node(nil), nodeFilter...),
+	}
+
+	seen := make(map[reflect.Type]bool)
+	for _, n := range nodeFilter {
+		seen[reflect.TypeOf(n)] = true
+	}
+
+	for _, c := range checks {
+		for _, n := range c.Nodes() {
+			if n == nil {
+				// A nil interface has no type to filter the traversal of the files on.
+				continue
+			}
+
+			t := reflect.TypeOf(n)
+			d.checks[t] = append(d.checks[t], c)
+			if !seen[t] {
+				seen[t] = true
+				d.filter = append(d.filter, n)
+			}
+		}
+	}
+
+	return d
+}
+
+// run runs the checks inspecting the type of node on it.
+func (d *checkDispatcher) run(pass *analysis.Pass, node ast.Node) {
+	if len(d.checks) == 0 {
+		return
+	}
+
+	for _, c := range d.checks[reflect.TypeOf(node)] {
+		rule := c.Rule()
+		c.Run(pass, node, func(rng analysis.Range, format string, args ...interface{}) {
+			d.linter.reportRange(pass, rule, rng, format, args...)
+		})
+	}
+}
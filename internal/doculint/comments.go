@@ -0,0 +1,357 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/doc/comment"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkDoc runs the checks that apply to the text of every doc comment on the comment
+// of a declaration of the given kind, named name and described by what. The name may
+// be empty for declarations that have none, such as blocks, which are treated as
+// exported.
+func (l *Linter) checkDoc(pass *analysis.Pass, kind, what, name string, pos token.Pos, doc *ast.CommentGroup) {
+	if doc == nil {
+		return
+	}
+
+	l.checkPeriod(pass, kind, what, pos, doc)
+	l.checkSentence(pass, kind, what, name, pos, doc)
+	l.checkDeprecated(pass, what, pos, doc)
+	l.checkDocLinks(pass, what, pos, doc)
+	l.checkLineLength(pass, doc)
+	l.checkBannedPhrases(pass, what, pos, doc)
+	l.checkGlossary(pass, what, doc)
+	l.checkURLs(pass, what, doc)
+	l.checkDocSyntax(pass, what, pos, doc)
+
+	if kind != kindPackage {
+		l.checkCommentStyle(pass, what, pos, doc)
+	}
+
+	if name == "" || ast.IsExported(name) {
+		l.checkMarkers(pass, what, pos, doc)
+		l.checkSpelling(pass, what, name, doc)
+		l.checkLanguage(pass, what, pos, doc)
+	}
+}
+
+// checkMarkers reports the markers in -markers, such as TODO, found in the doc comment
+// of an exported declaration described by what, since they would be published as part
+// of its documentation.
+func (l *Linter) checkMarkers(pass *analysis.Pass, what string, pos token.Pos, doc *ast.CommentGroup) {
+	text := doc.Text()
+
+	for _, marker := range l.todoMarkers {
+		if containsWord(text, marker) {
+			l.report(pass, RuleCommentMarker, pos, "comment for %s contains \"%s\", which will be published in its documentation", what, marker)
+		}
+	}
+}
+
+// checkBannedPhrases reports the phrases in -banned-phrases found in the doc comment of
+// a declaration described by what, outside of code blocks, such as phrases referring
+// to the declaration rather than using the "Foo does X" voice of godoc.
+func (l *Linter) checkBannedPhrases(pass *analysis.Pass, what string, pos token.Pos, doc *ast.CommentGroup) {
+	raw := doc.Text()
+
+	var prose strings.Builder
+	prose.Grow(len(raw))
+	for rest, kept := raw, false; rest != ""; {
+		var line string
+		line, rest, _ = strings.Cut(rest, "\n")
+		if indentation(line) != 0 {
+			continue
+		}
+
+		if kept {
+			prose.WriteByte(' ')
+		}
+		prose.WriteString(line)
+		kept = true
+	}
+	text := strings.ToLower(prose.String())
+
+	for _, phrase := range l.bannedPhrases {
+		if containsPhrase(text, strings.ToLower(phrase)) {
+			l.report(pass, RuleBannedPhrase, pos, "comment for %s contains \"%s\", describe what it does instead", what, phrase)
+		}
+	}
+}
+
+// containsPhrase reports whether text contains phrase, neither immediately preceded nor
+// followed by a letter, digit, or underscore.
+func containsPhrase(text, phrase string) bool {
+	return indexPhrase(text, phrase) >= 0
+}
+
+// indexPhrase returns the index of the first instance of phrase in text that is neither
+// immediately preceded nor followed by a letter, digit, or underscore, or -1 if there
+// is none.
+func indexPhrase(text, phrase string) int {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], phrase)
+		if i < 0 {
+			return -1
+		}
+		start, end := offset+i, offset+i+len(phrase)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (start == 0 || isNotWordRune(before)) && (end == len(text) || isNotWordRune(after)) {
+			return start
+		}
+
+		offset = start + 1
+	}
+}
+
+// terminalPunctuation contains the characters a doc comment may end with.
+const terminalPunctuation = ".!?"
+
+// closingPunctuation contains the characters that may trail the terminal punctuation
+// of a sentence, such as a closing parenthesis or quote.
+const closingPunctuation = ")]\"'`"
+
+// checkPeriod reports the doc comment of a declaration of the given kind, described by
+// what, if its final sentence does not end with terminal punctuation and the kind is
+// part of requirePeriod. The diagnostic carries a fix that appends a period, which is
+// never suggested for comments ending in a code block or a list.
+func (l *Linter) checkPeriod(pass *analysis.Pass, kind, what string, pos token.Pos, doc *ast.CommentGroup) {
+	if doc == nil || !l.requirePeriod[kind] {
+		return
+	}
+
+	text := strings.TrimRight(doc.Text(), "\n")
+	if text == "" {
+		return
+	}
+
+	// Comments ending in a code block, a list, or a heading, as godoc parses them, have
+	// no final sentence to punctuate.
+	parsed := new(comment.Parser).Parse(text)
+	if len(parsed.Content) == 0 {
+		return
+	}
+	if _, ok := parsed.Content[len(parsed.Content)-1].(*comment.Paragraph); !ok {
+		return
+	}
+
+	last := strings.TrimRight(text[strings.LastIndex(text, "\n")+1:], closingPunctuation+" ")
+	if last != "" && strings.ContainsRune(terminalPunctuation, rune(last[len(last)-1])) {
+		return
+	}
+
+	end := endOfText(doc)
+	if !end.IsValid() {
+		return
+	}
+
+	l.reportDiagnostic(pass, RuleCommentPeriod, analysis.Diagnostic{
+		Pos:     pos,
+		Message: "comment for " + what + " should end with a period",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Append a period",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     end,
+				End:     end,
+				NewText: []byte("."),
+			}},
+		}},
+	})
+}
+
+// indentation returns the number of leading space and tab characters in line.
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// checkSentence reports the doc comment of a declaration of the given kind, described
+// by what, if the kind is part of requireSentence and the comment does not begin with
+// either name, as a whole word, or a capital letter, or if its first sentence is a
+// fragment with no verb, such as "Foo helper function.". The name may be empty for
+// declarations that have none, such as blocks.
+func (l *Linter) checkSentence(pass *analysis.Pass, kind, what, name string, pos token.Pos, doc *ast.CommentGroup) {
+	if doc == nil || !l.requireSentence[kind] {
+		return
+	}
+
+	text := strings.TrimSpace(doc.Text())
+	if text == "" {
+		return
+	}
+
+	words := strings.Fields(firstSentence(text))
+	if name != "" && strings.TrimRightFunc(words[0], unicode.IsPunct) == name {
+		// The name is not checked, since it is an identifier rather than a word.
+		words = words[1:]
+	} else if r, _ := utf8.DecodeRuneInString(text); unicode.IsLower(r) {
+		l.report(pass, RuleCommentSentence, pos, "comment for %s should begin with a capital letter", what)
+		return
+	}
+
+	for _, word := range words {
+		if isSentenceVerb(strings.TrimFunc(word, unicode.IsPunct)) {
+			return
+		}
+	}
+
+	l.report(pass, RuleCommentSentence, pos, "comment for %s should be a complete sentence", what)
+}
+
+// endOfText returns the position just after the last character of text in the given
+// comment group, ignoring trailing whitespace, comment markers, and directives. It
+// returns token.NoPos if the group contains no text.
+func endOfText(doc *ast.CommentGroup) token.Pos {
+	for i := len(doc.List) - 1; i >= 0; i-- {
+		c := doc.List[i]
+
+		if isDirective(c.Text) {
+			continue
+		}
+
+		var body string
+		if strings.HasPrefix(c.Text, "/*") {
+			body = strings.TrimRight(strings.TrimSuffix(c.Text, "*/"), " \t\r\n*")
+			if len(body) <= len("/*") {
+				continue
+			}
+		} else {
+			body = strings.TrimRight(c.Text, " \t\r")
+			if len(body) <= len("//") {
+				continue
+			}
+		}
+
+		return c.Pos() + token.Pos(len(body))
+	}
+
+	return token.NoPos
+}
+
+// onlyDirective returns the first comment of doc if doc consists only of machine
+// readable directives, such as "//go:noinline" or "//nolint", which are not
+// documentation, or an empty string otherwise.
+func onlyDirective(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+
+	for _, c := range doc.List {
+		if !isDirective(c.Text) && !nolintPattern.MatchString(c.Text) {
+			return ""
+		}
+	}
+
+	return doc.List[0].Text
+}
+
+// isDirective reports whether the given comment, including its comment markers, is a
+// machine readable directive such as "//go:generate" or "//export Name" rather than
+// documentation. This matches the directives ast.CommentGroup.Text omits.
+func isDirective(comment string) bool {
+	if !strings.HasPrefix(comment, "//") {
+		return false
+	}
+	c := comment[len("//"):]
+
+	for _, prefix := range []string{"line ", "extern ", "export "} {
+		if strings.HasPrefix(c, prefix) {
+			return true
+		}
+	}
+
+	colon := strings.Index(c, ":")
+	if colon <= 0 || colon+1 >= len(c) {
+		return false
+	}
+
+	for i := 0; i <= colon+1; i++ {
+		if i == colon {
+			continue
+		}
+
+		b := c[i]
+		if !('a' <= b && b <= 'z' || '0' <= b && b <= '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkPackageCommentLength reports the package comment of file if it has fewer words
+// than -package-words or fewer sentences than -package-sentences, so that a comment
+// consisting of only "Package foo" does not document a package.
+func (l *Linter) checkPackageCommentLength(pass *analysis.Pass, file *ast.File) {
+	text := file.Doc.Text()
+
+	if words := len(strings.Fields(text)); words < l.minPackageWords {
+		l.report(pass, RulePackageCommentLength, file.Package, "comment for package \"%s\" has %d words but should have at least %d", pass.Pkg.Name(), words, l.minPackageWords)
+	}
+
+	if sentences := countSentences(text); sentences < l.minPackageSentences {
+		l.report(pass, RulePackageCommentLength, file.Package, "comment for package \"%s\" has %d sentences but should have at least %d", pass.Pkg.Name(), sentences, l.minPackageSentences)
+	}
+}
+
+// countSentences returns the number of sentences in the given comment text, which is
+// the number of terminal punctuation characters followed by whitespace or the end of
+// the text, plus one for trailing text without terminal punctuation.
+func countSentences(text string) int {
+	text = strings.TrimSpace(text)
+
+	n := 0
+	for i := 0; i < len(text); i++ {
+		if strings.IndexByte(terminalPunctuation, text[i]) >= 0 && (i+1 == len(text) || unicode.IsSpace(rune(text[i+1]))) {
+			n++
+		}
+	}
+
+	if text != "" && strings.IndexByte(terminalPunctuation, text[len(text)-1]) < 0 {
+		n++
+	}
+
+	return n
+}
+
+// firstSentence returns the first sentence of the given comment text, which is the
+// text up to and including the first period followed by whitespace, or the first
+// paragraph if it contains no such period.
+func firstSentence(text string) string {
+	text = strings.TrimSpace(text)
+
+	if i := strings.Index(text, "\n\n"); i >= 0 {
+		text = text[:i]
+	}
+
+	for i := 0; i < len(text)-1; i++ {
+		if text[i] == '.' && unicode.IsSpace(rune(text[i+1])) {
+			return text[:i+1]
+		}
+	}
+
+	return text
+}
+
+// containsWord reports whether text contains word as a whole word, meaning it is not
+// immediately preceded or followed by another letter, digit, or underscore.
+func containsWord(text, word string) bool {
+	// Words made of other characters are never delimited by them, since they are
+	// delimiters themselves.
+	if word == "" || strings.IndexFunc(word, isNotWordRune) >= 0 {
+		return false
+	}
+
+	return indexPhrase(text, word) >= 0
+}
+
+// isNotWordRune reports whether r cannot be part of a Go identifier.
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+}
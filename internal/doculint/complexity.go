@@ -0,0 +1,55 @@
+package doculint
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// minComplexSentences is the number of sentences the comments of complex functions need.
+const minComplexSentences = 2
+
+// checkComplexity reports the exported function fn if it takes at least
+// -multi-sentence-params parameters or its body spans at least -multi-sentence-lines
+// lines, and its comment has fewer than two sentences, since a sentence echoing the
+// name of a complex function rarely documents it.
+func (l *Linter) checkComplexity(pass *analysis.Pass, fn *ast.FuncDecl) {
+	if !fn.Name.IsExported() || fn.Doc == nil {
+		return
+	}
+
+	params := 0
+	for _, field := range fn.Type.Params.List {
+		params += max(len(field.Names), 1)
+	}
+
+	lines := 0
+	if fn.Body != nil {
+		lines = pass.Fset.Position(fn.Body.Rbrace).Line - pass.Fset.Position(fn.Body.Lbrace).Line - 1
+	}
+
+	if countSentences(fn.Doc.Text()) >= minComplexSentences {
+		return
+	}
+
+	switch {
+	case l.minComplexParams > 0 && params >= l.minComplexParams:
+		l.report(pass, RuleMultiSentence, fn.Pos(), "comment for function \"%s\", which has %d parameters, should have at least %d sentences", fn.Name.Name, params, minComplexSentences)
+	case l.minComplexLines > 0 && lines >= l.minComplexLines:
+		l.report(pass, RuleMultiSentence, fn.Pos(), "comment for function \"%s\", which has %d lines, should have at least %d sentences", fn.Name.Name, lines, minComplexSentences)
+	}
+}
+
+// checkInterfaceContract reports the comment doc of the exported interface type ts if
+// it has fewer than -interface-sentences sentences. Interface comments are the primary
+// specification for implementers, so a sentence introducing the name of the interface
+// needs to be followed by a description of the behavior expected of implementations.
+func (l *Linter) checkInterfaceContract(pass *analysis.Pass, ts *ast.TypeSpec, doc *ast.CommentGroup) {
+	if _, ok := ts.Type.(*ast.InterfaceType); !ok || l.minInterfaceSentences <= 0 || !ts.Name.IsExported() {
+		return
+	}
+
+	if sentences := countSentences(doc.Text()); sentences < l.minInterfaceSentences {
+		l.report(pass, RuleInterfaceContract, ts.Pos(), "comment for interface \"%s\" has %d sentences but should have at least %d describing the contract of its implementations", ts.Name.Name, sentences, l.minInterfaceSentences)
+	}
+}
@@ -0,0 +1,270 @@
+package doculint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Modes for how type blocks and the types within them are documented.
+const (
+	// blockModeStrict requires both the block and every type in it to have a comment.
+	blockModeStrict = "strict"
+
+	// blockModeRelaxed requires either the block or every type in it to have a comment,
+	// allowing a documented block to document the types in it.
+	blockModeRelaxed = "relaxed"
+)
+
+// blockMode is a mode for how type blocks are documented, used as a flag.Value.
+type blockMode string
+
+// String returns the name of the mode.
+func (m *blockMode) String() string {
+	return string(*m)
+}
+
+// Set sets the mode from its name.
+func (m *blockMode) Set(s string) error {
+	switch s {
+	case blockModeStrict, blockModeRelaxed:
+		*m = blockMode(s)
+		return nil
+	}
+
+	return fmt.Errorf("unknown type block mode \"%s\", expected %s or %s", s, blockModeStrict, blockModeRelaxed)
+}
+
+// UnmarshalText sets the mode from its name, validating modes read from the
+// configuration file.
+func (m *blockMode) UnmarshalText(text []byte) error {
+	return m.Set(string(text))
+}
+
+// config is the configuration file given through the -config flag.
+type config struct {
+	// Packages maps package patterns to the settings used for matching packages. A
+	// pattern is either an import path or an import path followed by "/...", which
+	// also matches every package beneath it. When several patterns match a package,
+	// the settings of the longer patterns take precedence.
+	Packages map[string]packageConfig `json:"packages"`
+
+	// Glossary maps the preferred terms of a project, such as "allowlist", to the
+	// deprecated synonyms reported when found in doc comments, such as "whitelist".
+	Glossary map[string][]string `json:"glossary"`
+
+	// Checks maps the IDs of the rules of registered checks to their settings, which are
+	// passed to the checks implementing ConfigurableCheck.
+	Checks map[string]json.RawMessage `json:"checks"`
+
+	// Rules are the rules defined by CEL expressions, added to the checks of the analyzer.
+	Rules []scriptRule `json:"rules"`
+
+	// Stubs maps declaration kinds to the templates of the comments inserted by the
+	// fixes of their missing comments in place of the default ones, such as
+	// "// {{.Name}} is part of the public API of {{.Package}}.".
+	Stubs map[string]string `json:"stubs"`
+
+	// stubs are the parsed templates of Stubs.
+	stubs map[string]*template.Template
+}
+
+// packageConfig are the settings of a set of packages in the configuration file. Unset
+// settings fall back to the value of the corresponding flag.
+type packageConfig struct {
+	// TypeBlocks overrides -type-blocks.
+	TypeBlocks *blockMode `json:"typeBlocks,omitempty"`
+
+	// ExemptSingleTypeBlocks overrides -exempt-single-type-blocks.
+	ExemptSingleTypeBlocks *bool `json:"exemptSingleTypeBlocks,omitempty"`
+
+	// PackageFile overrides -package-file.
+	PackageFile *string `json:"packageFile,omitempty"`
+
+	// GroupBlocks overrides -group-blocks.
+	GroupBlocks *int `json:"groupBlocks,omitempty"`
+
+	// Examples overrides -examples.
+	Examples *int `json:"examples,omitempty"`
+
+	// IotaEnums overrides -iota-enums.
+	IotaEnums *blockMode `json:"iotaEnums,omitempty"`
+
+	// InitDocs overrides -init-docs.
+	InitDocs *bool `json:"initDocs,omitempty"`
+
+	// GenerateDocs overrides -generate-docs.
+	GenerateDocs *bool `json:"generateDocs,omitempty"`
+
+	// WellKnownMethods overrides -well-known-methods.
+	WellKnownMethods *methodMode `json:"wellKnownMethods,omitempty"`
+}
+
+// packageSettings are the effective settings for a package, resolved from the flags
+// and the configuration file.
+type packageSettings struct {
+	// typeBlocks is the mode for how type blocks are documented.
+	typeBlocks blockMode
+
+	// exemptSingleTypeBlocks controls whether type blocks that contain a single type
+	// are treated as if the type was not in a block.
+	exemptSingleTypeBlocks bool
+
+	// packageFile is the name of the file that must contain the package comment, or
+	// empty for the file named after the package.
+	packageFile string
+
+	// groupBlocks is the number of entries above which constant and variable blocks
+	// must be split into commented groups, or 0 to disable the check.
+	groupBlocks int
+
+	// examples is the number of exported functions and types from which a package must
+	// have an example for each of them, or 0 to disable the check.
+	examples int
+
+	// iotaEnums is the mode for how the members of iota enum blocks are documented.
+	iotaEnums blockMode
+
+	// initDocs controls whether init functions must have a comment explaining their
+	// side effects.
+	initDocs bool
+
+	// generateDocs controls whether //go:generate directives must be preceded by a
+	// comment explaining them.
+	generateDocs bool
+
+	// wellKnownMethods is the mode for how the methods implementing well-known
+	// interfaces, such as String, are documented.
+	wellKnownMethods methodMode
+}
+
+// loadConfig returns the configuration file given through the -config flag, reading it
+// the first time it is called. An empty configuration is returned if no file was given.
+func (l *Linter) loadConfig() (config, error) {
+	l.loaded.once.Do(func() {
+		if l.configPath == "" {
+			return
+		}
+
+		data, err := os.ReadFile(l.configPath)
+		if err != nil {
+			l.loaded.err = fmt.Errorf("read config: %w", err)
+			return
+		}
+
+		if err := json.Unmarshal(data, &l.loaded.cfg); err != nil {
+			l.loaded.err = fmt.Errorf("parse config %s: %w", l.configPath, err)
+			return
+		}
+
+		l.loaded.cfg.stubs, err = compileStubs(l.loaded.cfg.Stubs)
+		if err != nil {
+			l.loaded.err = fmt.Errorf("config %s: %w", l.configPath, err)
+		}
+	})
+
+	return l.loaded.cfg, l.loaded.err
+}
+
+// Reload makes l read the files named by its flags again, such as the configuration
+// file, the next time it analyzes a package, so that long-lived processes pick up their
+// changes. It must not be called while l analyzes packages.
+func (l *Linter) Reload() {
+	l.loaded.once, l.loaded.cfg, l.loaded.err = sync.Once{}, config{}, nil
+	l.checks.once, l.checks.list, l.checks.rules, l.checks.dispatcher, l.checks.err = sync.Once{}, nil, nil, nil, nil
+	l.header.once, l.header.text, l.header.pattern, l.header.err = sync.Once{}, "", nil, nil
+	l.dictionary.once, l.dictionary.words, l.dictionary.err = sync.Once{}, nil, nil
+}
+
+// settingsFor resolves the settings of l for the package with the given import path,
+// from its flags and the configuration file c.
+func (l *Linter) settingsFor(c config, path string) packageSettings {
+	s := packageSettings{
+		typeBlocks:             l.typeBlocks,
+		exemptSingleTypeBlocks: l.exemptSingleTypeBlocks,
+		packageFile:            l.packageFile,
+		groupBlocks:            l.groupBlocks,
+		examples:               l.requireExamples,
+		iotaEnums:              l.iotaEnums,
+		initDocs:               l.requireInitDocs,
+		generateDocs:           l.requireGenerateDocs,
+		wellKnownMethods:       l.wellKnownMethodDocs,
+	}
+
+	var patterns []string
+	for pattern := range c.Packages {
+		if matchPackage(pattern, path) {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	// Apply the least specific patterns first so that more specific ones override them.
+	sort.Slice(patterns, func(i, j int) bool {
+		return len(patterns[i]) < len(patterns[j])
+	})
+
+	for _, pattern := range patterns {
+		pc := c.Packages[pattern]
+
+		if pc.TypeBlocks != nil {
+			s.typeBlocks = *pc.TypeBlocks
+		}
+
+		if pc.ExemptSingleTypeBlocks != nil {
+			s.exemptSingleTypeBlocks = *pc.ExemptSingleTypeBlocks
+		}
+
+		if pc.PackageFile != nil {
+			s.packageFile = *pc.PackageFile
+		}
+
+		if pc.GroupBlocks != nil {
+			s.groupBlocks = *pc.GroupBlocks
+		}
+
+		if pc.Examples != nil {
+			s.examples = *pc.Examples
+		}
+
+		if pc.IotaEnums != nil {
+			s.iotaEnums = *pc.IotaEnums
+		}
+
+		if pc.InitDocs != nil {
+			s.initDocs = *pc.InitDocs
+		}
+
+		if pc.GenerateDocs != nil {
+			s.generateDocs = *pc.GenerateDocs
+		}
+
+		if pc.WellKnownMethods != nil {
+			s.wellKnownMethods = *pc.WellKnownMethods
+		}
+	}
+
+	return s
+}
+
+// packageFileName returns the name of the file that must contain the comment of the
+// package with the given name.
+func (s packageSettings) packageFileName(pkg string) string {
+	if s.packageFile != "" {
+		return s.packageFile
+	}
+
+	return pkg + ".go"
+}
+
+// matchPackage reports whether the package pattern matches the given import path.
+func matchPackage(pattern, path string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return path == prefix || strings.HasPrefix(path, prefix+"/")
+	}
+
+	return pattern == path
+}
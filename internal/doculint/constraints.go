@@ -0,0 +1,84 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkBuildConstraint reports file if it has a //go:build constraint but no comment
+// explaining why the constraint exists, when -build-constraint-docs is set. The
+// explanation is a comment placed before the package clause, other than the package
+// comment and copyright or license headers, or after the package clause but before the
+// first declaration.
+func (l *Linter) checkBuildConstraint(pass *analysis.Pass, file *ast.File) {
+	if !l.requireConstraintDocs {
+		return
+	}
+
+	var build *ast.Comment
+	for _, cg := range file.Comments {
+		if cg.Pos() > file.Package {
+			break
+		}
+
+		for _, c := range cg.List {
+			if constraint.IsGoBuild(c.Text) {
+				build = c
+			}
+		}
+	}
+
+	if build == nil {
+		return
+	}
+
+	end := file.FileEnd
+	if len(file.Decls) > 0 {
+		end = file.Decls[0].Pos()
+	}
+
+	for _, cg := range file.Comments {
+		if cg.Pos() >= end {
+			break
+		}
+
+		if cg == file.Doc || isLicenseHeader(cg) || docOfDecl(file, cg) {
+			continue
+		}
+
+		for _, c := range cg.List {
+			if !isDirective(c.Text) && !constraint.IsPlusBuild(c.Text) && !appliesToDoculint(c.Text) {
+				return
+			}
+		}
+	}
+
+	expr := strings.TrimSpace(strings.TrimPrefix(build.Text, "//go:build"))
+	l.report(pass, RuleBuildConstraint, build.Pos(), "file with build constraint \"%s\" should have a comment explaining why the constraint exists", expr)
+}
+
+// isLicenseHeader reports whether the comment group is a copyright or license header.
+func isLicenseHeader(cg *ast.CommentGroup) bool {
+	text := strings.ToLower(cg.Text())
+	return strings.HasPrefix(text, "copyright") || strings.Contains(text, "license")
+}
+
+// docOfDecl reports whether the comment group is the doc comment of the first
+// declaration of file.
+func docOfDecl(file *ast.File, cg *ast.CommentGroup) bool {
+	if len(file.Decls) == 0 {
+		return false
+	}
+
+	switch decl := file.Decls[0].(type) {
+	case *ast.FuncDecl:
+		return decl.Doc == cg
+	case *ast.GenDecl:
+		return decl.Doc == cg
+	}
+
+	return false
+}
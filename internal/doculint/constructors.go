@@ -0,0 +1,60 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// constructorPrefix is the prefix of the names of constructors.
+const constructorPrefix = "New"
+
+// checkConstructor reports the comment of the function fn if fn is a constructor, an
+// exported function named New or NewXxx whose first result is of a named type, and its
+// comment does not mention the name of that type, when -constructor-docs is set. This
+// catches constructors documented only as creating "a new instance".
+func (l *Linter) checkConstructor(pass *analysis.Pass, fn *ast.FuncDecl) {
+	if !l.requireConstructorDocs || fn.Recv != nil || !isConstructorName(fn.Name.Name) {
+		return
+	}
+
+	obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func)
+	if !ok || obj.Signature().Results().Len() == 0 {
+		return
+	}
+
+	typ := obj.Signature().Results().At(0).Type()
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	var name string
+	switch t := typ.(type) {
+	case *types.Alias:
+		name = t.Obj().Name()
+	case *types.Named:
+		name = t.Obj().Name()
+	default:
+		return
+	}
+
+	if !containsWord(strings.TrimPrefix(strings.TrimSpace(fn.Doc.Text()), fn.Name.Name), name) {
+		l.report(pass, RuleConstructorComment, fn.Pos(), "comment for constructor \"%s\" should mention the type \"%s\" it returns, as in \"%s returns a %s ...\"", fn.Name.Name, name, fn.Name.Name, name)
+	}
+}
+
+// isConstructorName reports whether name is the name of a constructor, which is New
+// alone or followed by an upper case letter.
+func isConstructorName(name string) bool {
+	rest, ok := strings.CutPrefix(name, constructorPrefix)
+	if !ok {
+		return false
+	}
+
+	r, _ := utf8.DecodeRuneInString(rest)
+	return rest == "" || unicode.IsUpper(r)
+}
@@ -0,0 +1,65 @@
+package doculint
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// deduplicate returns a copy of pass whose Report function holds back diagnostics until
+// the returned flush function is called. Diagnostics reported by the same rule with the
+// same message on the same line, such as both sides of a comparison of two literals, are
+// then reported once, annotated with the number of occurrences. Diagnostics carrying
+// suggested fixes or related information are never merged, since they are specific to
+// their position.
+func (l *Linter) deduplicate(pass *analysis.Pass) (*analysis.Pass, func()) {
+	if !l.dedupFindings {
+		return pass, func() {}
+	}
+
+	type key struct {
+		file     *token.File
+		line     int
+		category string
+		message  string
+	}
+
+	// held is a diagnostic held back, along with the number of times it was reported.
+	type held struct {
+		diag  analysis.Diagnostic
+		count int
+	}
+
+	var diags []held
+	index := make(map[key]int)
+
+	p := *pass
+	p.Report = func(diag analysis.Diagnostic) {
+		if len(diag.SuggestedFixes) == 0 && len(diag.Related) == 0 && diag.Pos.IsValid() {
+			tf := pass.Fset.File(diag.Pos)
+			k := key{tf, tf.Line(diag.Pos), diag.Category, diag.Message}
+			if i, ok := index[k]; ok {
+				diags[i].count++
+				if diag.End > diags[i].diag.End {
+					diags[i].diag.End = diag.End
+				}
+				return
+			}
+			index[k] = len(diags)
+		}
+
+		diags = append(diags, held{diag, 1})
+	}
+
+	flush := func() {
+		for _, h := range diags {
+			if h.count > 1 {
+				h.diag.Message = fmt.Sprintf("%s (%d occurrences)", h.diag.Message, h.count)
+			}
+			pass.Report(h.diag)
+		}
+	}
+
+	return &p, flush
+}
@@ -0,0 +1,46 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// deprecatedPrefix is the canonical beginning of a deprecation notice paragraph, as
+// recognized by godoc and staticcheck.
+const deprecatedPrefix = "Deprecated: "
+
+// deprecationParagraph matches the beginning of a paragraph that is intended to be a
+// deprecation notice, including misspelled and miscased variants.
+var deprecationParagraph = regexp.MustCompile(`(?i)^(deprecated|depreciated|deprecation)\b`)
+
+// deprecationInline matches a deprecation notice that is not at the beginning of a
+// paragraph.
+var deprecationInline = regexp.MustCompile(`(?i)[^\n]\s*\b(deprecated|depreciated):`)
+
+// checkDeprecated reports deprecation notices in the doc comment of a declaration,
+// described by what, that godoc and staticcheck will not recognize because they are
+// not in their own paragraph or do not begin with deprecatedPrefix.
+func (l *Linter) checkDeprecated(pass *analysis.Pass, what string, pos token.Pos, doc *ast.CommentGroup) {
+	if !l.checkDeprecation {
+		return
+	}
+
+	for _, paragraph := range strings.Split(strings.TrimSpace(doc.Text()), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+
+		if deprecationParagraph.MatchString(paragraph) {
+			if !strings.HasPrefix(paragraph, deprecatedPrefix) {
+				l.report(pass, RuleDeprecated, pos, "deprecation notice in comment for %s should begin with \"%s\"", what, deprecatedPrefix)
+			}
+			continue
+		}
+
+		if deprecationInline.MatchString(paragraph) {
+			l.report(pass, RuleDeprecated, pos, "deprecation notice in comment for %s should be in its own paragraph", what)
+		}
+	}
+}
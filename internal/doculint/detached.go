@@ -0,0 +1,178 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// tokenKinds maps the tokens of declarations to the way the declared identifiers are
+// described in diagnostics.
+var tokenKinds = map[token.Token]string{
+	token.CONST: "constant",
+	token.TYPE:  "type",
+	token.VAR:   "variable",
+}
+
+// checkDetachedComments reports the comments of file that appear to document a
+// declaration, because they begin with its name, but are separated from it by a blank
+// line, so that neither the compiler nor godoc associate them with the declaration. The
+// diagnostics carry a fix removing the blank lines.
+func (l *Linter) checkDetachedComments(pass *analysis.Pass, file *ast.File) {
+	if file.Doc == nil {
+		l.checkDetached(pass, file, file.FileStart, file.Package, "Package "+file.Name.Name, "package", file.Name.Name)
+	}
+
+	prev := file.Name.End()
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Doc == nil {
+				l.checkDetached(pass, file, prev, decl.Pos(), decl.Name.Name, "function", decl.Name.Name)
+			}
+		case *ast.GenDecl:
+			kind, ok := tokenKinds[decl.Tok]
+			if !ok {
+				break
+			}
+
+			if !decl.Lparen.IsValid() {
+				if name := specName(decl.Specs[0]); decl.Doc == nil && name != "" {
+					l.checkDetached(pass, file, prev, decl.Pos(), name, kind, name)
+				}
+				break
+			}
+
+			from := decl.Lparen
+			for _, spec := range decl.Specs {
+				if name := specName(spec); specDoc(spec) == nil && name != "" {
+					l.checkDetached(pass, file, from, spec.Pos(), name, kind, name)
+				}
+				from = spec.End()
+			}
+		}
+
+		prev = decl.End()
+	}
+}
+
+// checkDetached reports the comment of file found by detachedComment for the
+// declaration of the given kind and name at pos, along with a fix removing the blank
+// lines separating them.
+func (l *Linter) checkDetached(pass *analysis.Pass, file *ast.File, from, pos token.Pos, prefix, kind, name string) {
+	detached := detachedComment(pass, file, from, pos, prefix)
+	if detached == nil {
+		return
+	}
+
+	tf := pass.Fset.File(pos)
+	l.reportDiagnostic(pass, RuleDetachedComment, analysis.Diagnostic{
+		Pos:     pos,
+		Message: fmt.Sprintf("comment for %s \"%s\" is separated from it by a blank line, so it is not associated with it", kind, name),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Remove the blank line",
+			TextEdits: []analysis.TextEdit{{
+				Pos: detached.End(),
+				End: tf.LineStart(tf.Line(pos)) - 1,
+			}},
+		}},
+	})
+}
+
+// detachedComment returns the last comment of file between from and the declaration
+// at pos, if it begins with prefix and is separated from the declaration by blank
+// lines only. Comments on the line of from, such as trailing comments of the previous
+// declaration, are ignored.
+func detachedComment(pass *analysis.Pass, file *ast.File, from, pos token.Pos, prefix string) *ast.CommentGroup {
+	// Comments are ordered by position, the first one at or after from being found
+	// without scanning those of the previous declarations.
+	first := sort.Search(len(file.Comments), func(i int) bool {
+		return file.Comments[i].Pos() >= from
+	})
+
+	var detached *ast.CommentGroup
+	for _, cg := range file.Comments[first:] {
+		if cg.End() > pos {
+			break
+		}
+		detached = cg
+	}
+
+	if detached == nil || !strings.HasPrefix(strings.TrimSpace(detached.Text()), prefix) {
+		return nil
+	}
+
+	tf := pass.Fset.File(pos)
+	start, end := tf.Line(detached.Pos()), tf.Line(detached.End())
+	if from.IsValid() && tf.Line(from) == start {
+		return nil
+	}
+
+	if end+1 >= tf.Line(pos) {
+		return nil
+	}
+
+	return detached
+}
+
+// hasDetachedComment reports whether the declaration at pos, of one of the files of the
+// package analyzed by pass, has a comment beginning with prefix separated from it by
+// blank lines, which checkDetachedComments reports along with a fix attaching it.
+func hasDetachedComment(pass *analysis.Pass, pos token.Pos, prefix string) bool {
+	for _, file := range pass.Files {
+		if file.FileStart <= pos && pos <= file.FileEnd {
+			return detachedComment(pass, file, precedingEnd(file, pos), pos, prefix) != nil
+		}
+	}
+
+	return false
+}
+
+// precedingEnd returns the end of what precedes the declaration at pos in file, from
+// which checkDetachedComments looks for its comment: the previous declaration, or the
+// previous specification or opening parenthesis of the block declaring it, the package
+// clause for the first declaration, and the start of the file for the package clause.
+func precedingEnd(file *ast.File, pos token.Pos) token.Pos {
+	if pos <= file.Package {
+		return file.FileStart
+	}
+
+	end := file.Name.End()
+	for _, decl := range file.Decls {
+		if decl.End() <= pos {
+			end = decl.End()
+			continue
+		}
+
+		if decl, ok := decl.(*ast.GenDecl); ok && decl.Lparen.IsValid() && decl.Lparen < pos {
+			end = decl.Lparen
+			for _, spec := range decl.Specs {
+				if spec.End() <= pos {
+					end = spec.End()
+				}
+			}
+		}
+		break
+	}
+
+	return end
+}
+
+// specName returns the name declared by spec, or an empty string if it declares more
+// than one name or none.
+func specName(spec ast.Spec) string {
+	switch spec := spec.(type) {
+	case *ast.ValueSpec:
+		if len(spec.Names) == 1 {
+			return spec.Names[0].Name
+		}
+	case *ast.TypeSpec:
+		return spec.Name.Name
+	}
+
+	return ""
+}
@@ -0,0 +1,162 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/doc/comment"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// unresolvedPackage prefixes the import path given to doc links whose package name
+// could not be resolved, so that they can be told apart from resolved links.
+const unresolvedPackage = "\x00"
+
+// checkDocLinks reports doc links, such as [Name] or [pkg.Name], in the doc comment of
+// a declaration, described by what, that do not resolve to an identifier in the
+// package or one of the packages imported by the file containing the declaration.
+// Such links are rendered as literal brackets by godoc and pkg.go.dev.
+func (l *Linter) checkDocLinks(pass *analysis.Pass, what string, pos token.Pos, doc *ast.CommentGroup) {
+	if !l.checkLinks {
+		return
+	}
+
+	imports := fileImports(pass, pos)
+
+	parser := comment.Parser{
+		LookupPackage: func(name string) (string, bool) {
+			if path, ok := imports[name]; ok {
+				return path, true
+			}
+
+			if path, ok := comment.DefaultLookupPackage(name); ok {
+				return path, true
+			}
+
+			return unresolvedPackage + name, true
+		},
+		LookupSym: func(recv, name string) bool {
+			// Accept every symbol so that broken links are parsed as links, they
+			// are resolved afterwards.
+			return true
+		},
+	}
+
+	walkDocLinks(parser.Parse(doc.Text()).Content, func(link *comment.DocLink) {
+		if msg := resolveDocLink(pass, link); msg != "" {
+			l.report(pass, RuleDocLink, pos, "doc link [%s] in comment for %s %s", docLinkText(link), what, msg)
+		}
+	})
+}
+
+// resolveDocLink resolves link, returning a description of why it is broken or an
+// empty string if it resolves. Links to packages that are not imported by the analyzed
+// package cannot be verified and are assumed to resolve.
+func resolveDocLink(pass *analysis.Pass, link *comment.DocLink) string {
+	if strings.HasPrefix(link.ImportPath, unresolvedPackage) {
+		if link.Name == "" {
+			// A lone bracketed lowercase word, such as [sic], is more likely prose
+			// than an attempt at a link.
+			return ""
+		}
+
+		return "refers to package \"" + strings.TrimPrefix(link.ImportPath, unresolvedPackage) + "\" which is not imported"
+	}
+
+	scope := pass.Pkg.Scope()
+	if link.ImportPath != "" {
+		scope = nil
+		for _, imported := range pass.Pkg.Imports() {
+			if imported.Path() == link.ImportPath {
+				scope = imported.Scope()
+				break
+			}
+		}
+
+		if scope == nil || link.Name == "" {
+			return ""
+		}
+	}
+
+	if link.Recv == "" {
+		if scope.Lookup(link.Name) == nil {
+			return "does not refer to a declared identifier"
+		}
+
+		return ""
+	}
+
+	recv, ok := scope.Lookup(link.Recv).(*types.TypeName)
+	if !ok {
+		return "does not refer to a declared type"
+	}
+
+	if obj, _, _ := types.LookupFieldOrMethod(recv.Type(), true, recv.Pkg(), link.Name); obj == nil {
+		return "does not refer to a field or method of \"" + link.Recv + "\""
+	}
+
+	return ""
+}
+
+// fileImports returns the names the file of pass containing pos refers to its imports
+// by, mapped to their import paths.
+func fileImports(pass *analysis.Pass, pos token.Pos) map[string]string {
+	imports := make(map[string]string)
+
+	for _, file := range pass.Files {
+		if pos < file.FileStart || pos > file.FileEnd {
+			continue
+		}
+
+		for _, spec := range file.Imports {
+			if name := pass.TypesInfo.PkgNameOf(spec); name != nil {
+				imports[name.Name()] = name.Imported().Path()
+			}
+		}
+	}
+
+	return imports
+}
+
+// walkDocLinks calls fn for every doc link within blocks.
+func walkDocLinks(blocks []comment.Block, fn func(*comment.DocLink)) {
+	for _, block := range blocks {
+		switch block := block.(type) {
+		case *comment.Paragraph:
+			walkTextDocLinks(block.Text, fn)
+		case *comment.Heading:
+			walkTextDocLinks(block.Text, fn)
+		case *comment.List:
+			for _, item := range block.Items {
+				walkDocLinks(item.Content, fn)
+			}
+		}
+	}
+}
+
+// walkTextDocLinks calls fn for every doc link within text.
+func walkTextDocLinks(text []comment.Text, fn func(*comment.DocLink)) {
+	for _, t := range text {
+		switch t := t.(type) {
+		case *comment.DocLink:
+			fn(t)
+		case *comment.Link:
+			walkTextDocLinks(t.Text, fn)
+		}
+	}
+}
+
+// docLinkText returns the text of link as it was written in the comment, without the
+// surrounding brackets.
+func docLinkText(link *comment.DocLink) string {
+	var b strings.Builder
+	for _, t := range link.Text {
+		if plain, ok := t.(comment.Plain); ok {
+			b.WriteString(string(plain))
+		}
+	}
+
+	return b.String()
+}
@@ -5,184 +5,539 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"regexp"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
 )
 
-// Analyzer exports the doculint analyzer (linter).
-var Analyzer = analysis.Analyzer{
-	Name: "doculint",
-	Doc:  "checks for proper function, type, package, constant, and string and numeric literal documentation",
-	Run:  doculint,
+// nodeFilter holds a node of each type visited by the traversal of the files of a
+// package.
+var nodeFilter = []ast.Node{
+	(*ast.File)(nil),
+	(*ast.FuncDecl)(nil),
+	(*ast.IfStmt)(nil),
+	(*ast.SwitchStmt)(nil),
+	(*ast.CallExpr)(nil),
+	(*ast.GenDecl)(nil),
+}
+
+// New returns a linter with the default settings, along with an analyzer of its own
+// whose flags set them.
+func New() *Linter {
+	l := &Linter{
+		requirePeriod:       make(kindSet),
+		requireSentence:     make(kindSet),
+		todoMarkers:         stringList{"TODO", "FIXME", "XXX"},
+		typeBlocks:          blockModeStrict,
+		bannedPhrases:       stringList{"this function", "this method", "simply", "obviously"},
+		iotaEnums:           blockModeStrict,
+		genericPackageNames: stringList{"util", "utils", "common", "helper", "helpers", "misc", "shared", "base"},
+		callLiteralExempt:   stringList{"make"},
+		allowedLiterals:     stringList{"0", "1", "-1", `""`},
+		configSuffixes:      stringList{"Options", "Config", "Params"},
+		errorDocPattern:     pattern{regexp.MustCompile(`(?i)\berr(or)?s?\b|fail`)},
+		wellKnownMethodDocs: methodModeStrict,
+	}
+
+	l.Analyzer = &analysis.Analyzer{
+		Name: "doculint",
+		Doc:  "checks for proper function, type, package, constant, and string and numeric literal documentation",
+		URL:  rulesURL,
+		Run:  l.doculint,
+
+		Requires:   []*analysis.Analyzer{inspect.Analyzer},
+		ResultType: reflect.TypeOf((*Result)(nil)),
+		FactTypes:  []analysis.Fact{new(docFact)},
+	}
+	l.registerFlags(&l.Analyzer.Flags)
+
+	return l
 }
 
 // doculint is the function that gets passed to the Analyzer which runs the actual
-// analysis for the doculint linter on a set of files.
-func doculint(pass *analysis.Pass) (interface{}, error) {
-	// packageWithSameNameFile keep track of which packages have a file with the same
-	// name as the package and which do not (the convention is that this file will
-	// contain the package documentation).
-	packageWithSameNameFile := make(map[string]bool)
+// analysis for the doculint linter on a set of files. Its result is a *Result.
+func (l *Linter) doculint(pass *analysis.Pass) (interface{}, error) {
+	cfg, err := l.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	checks, err := l.loadChecks()
+	if err != nil {
+		return nil, err
+	}
+	settings := l.settingsFor(cfg, pass.Pkg.Path())
+	pass = withoutCgoFiles(pass)
 
-	if msg := validatePackageName(pass.Pkg.Name()); msg != "" {
-		pass.Reportf(0, msg)
+	// Dependencies loaded from export data, which are analyzed for their facts, have no
+	// files.
+	l.exportDocFacts(pass)
+	if len(pass.Files) == 0 {
+		return &Result{}, nil
 	}
 
-	for _, file := range pass.Files {
-		if pass.Pkg.Name() != "main" {
-			// Ignore the main package, it doesn't need a package comment.
+	if l.checkSpell {
+		if _, err := l.loadDictionary(); err != nil {
+			return nil, err
+		}
+	}
 
-			// Add this package to packageWithSameNameFile if it does not already
-			// exist.
-			if _, exists := packageWithSameNameFile[pass.Pkg.Name()]; !exists {
-				packageWithSameNameFile[pass.Pkg.Name()] = false
-			}
+	if l.headerPath != "" {
+		if _, _, err := l.loadHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	// The convention is that the package file, named after the package or doc.go, will
+	// contain the package documentation.
+	filename := settings.packageFileName(pass.Pkg.Name())
+
+	// The explanations of suppressions are checked before applying them, since they
+	// would otherwise suppress the issues found with them.
+	l.checkNolintReasons(pass)
+
+	result := &Result{Suppressions: findSuppressions(pass, filename)}
+	pass = l.suppress(pass, result.Suppressions)
 
-			if file.Name.Name == pass.Pkg.Name() {
-				packageWithSameNameFile[pass.Pkg.Name()] = true
+	pass, flush := l.deduplicate(pass)
+	defer flush()
 
-				if file.Doc == nil {
-					pass.Reportf(0, "package \"%s\" has no comment associated with it in \"%s.go\"", pass.Pkg.Name(), pass.Pkg.Name())
+	l.checkPackageName(pass, packagePos(pass, filename))
+
+	// Ignore the main package, it doesn't need a package comment, and packages made of
+	// only test files, which are not documented.
+	checkPackageDoc := pass.Pkg.Name() != "main" && !onlyTestFiles(pass)
+	hasPackageFile := false
+
+	for _, file := range pass.Files {
+		if checkPackageDoc && filepath.Base(pass.Fset.Position(file.Package).Filename) == filename {
+			hasPackageFile = true
+
+			if file.Doc == nil {
+				if misplaced := misplacedPackageComment(pass, filename); misplaced != "" {
+					l.reportRange(pass, RulePackageComment, file.Name, "package \"%s\" has no comment associated with it in \"%s\", move the comment found in \"%s\" to it", pass.Pkg.Name(), filename, misplaced)
 				} else {
-					expectedPrefix := fmt.Sprintf("Package %s", pass.Pkg.Name())
-					if !strings.HasPrefix(strings.TrimSpace(file.Doc.Text()), expectedPrefix) {
-						pass.Reportf(0, "comment for package \"%s\" should begin with \"%s\"", pass.Pkg.Name(), expectedPrefix)
-					}
+					l.reportMissingDoc(pass, RulePackageComment, file.Name, file.Package, stubData{Name: pass.Pkg.Name(), Kind: kindPackage}, "package \"%s\" has no comment associated with it in \"%s\"", pass.Pkg.Name(), filename)
 				}
+			} else {
+				expectedPrefix := fmt.Sprintf("Package %s", pass.Pkg.Name())
+				if !strings.HasPrefix(strings.TrimSpace(file.Doc.Text()), expectedPrefix) {
+					l.reportPrefix(pass, RulePackageComment, file.Name, file.Doc, expectedPrefix, "comment for package \"%s\" should begin with \"%s\"", pass.Pkg.Name(), expectedPrefix)
+				}
+
+				l.checkDoc(pass, kindPackage, fmt.Sprintf("package \"%s\"", pass.Pkg.Name()), "Package", file.Package, file.Doc)
+				l.checkPackageCommentLength(pass, file)
 			}
 		}
 
-		ast.Inspect(file, func(n ast.Node) bool {
-			switch expr := n.(type) {
-			case *ast.FuncDecl:
-				if pass.Pkg.Name() == "main" && expr.Name.Name == "main" {
-					// Ignore func main in main package.
-					return true
-				}
+		l.checkHeader(pass, file)
+		l.checkErrorSentinels(pass, file)
+		l.checkDetachedComments(pass, file)
+		l.checkGenerateDirectives(pass, settings.generateDocs, file)
+		l.checkBuildConstraint(pass, file)
+		l.checkStutter(pass, file)
+	}
 
-				if expr.Name.Name == "init" {
-					// Ignore init functions.
-					return true
-				}
+	// The nodes of the files are visited in a single traversal of the package, files
+	// generated by cgo excluded, through the inspector shared with other analyzers.
+	var file *ast.File
+	skip := false
+	pass.ResultOf[inspect.Analyzer].(*inspector.Inspector).Preorder(checks.filter, func(n ast.Node) {
+		if f, ok := n.(*ast.File); ok {
+			file = f
+			skip = isCgoGenerated(pass, f)
+		}
+		if skip {
+			return
+		}
 
-				if expr.Doc == nil {
-					pass.Reportf(expr.Pos(), "function \"%s\" has no comment associated with it", expr.Name.Name)
-					return true
-				}
+		checks.run(pass, n)
 
-				if !strings.HasPrefix(strings.TrimSpace(expr.Doc.Text()), expr.Name.Name) {
-					pass.Reportf(expr.Pos(), "comment for function \"%s\" should begin with \"%s\"", expr.Name.Name, expr.Name.Name)
-					return true
-				}
-			case *ast.IfStmt:
-				be, ok := expr.Cond.(*ast.BinaryExpr)
-				if !ok {
-					return true
+		switch expr := n.(type) {
+		case *ast.FuncDecl:
+			l.checkExits(pass, expr)
+			l.checkPanics(pass, expr)
+			l.checkReturnLiterals(pass, expr)
+
+			if pass.Pkg.Name() == "main" && expr.Name.Name == "main" {
+				// Ignore func main in main package.
+				return
+			}
+
+			if expr.Name.Name == "init" && expr.Recv == nil {
+				// Init functions are ignored unless they must explain their side
+				// effects, which godoc does not show.
+				if settings.initDocs && (expr.Doc == nil || onlyDirective(expr.Doc) != "") {
+					l.reportRange(pass, RuleFunctionComment, expr.Name, "function \"init\" has no comment explaining its side effects")
 				}
+				return
+			}
+
+			if kind := testFunctionKind(pass, expr); kind != "" {
+				// Functions run by go test are described by their names, except for
+				// benchmarks and fuzz tests when they must document what they exercise.
+				l.checkTestFunctionDoc(pass, kind, expr)
+				return
+			}
+
+			iface := wellKnownInterface(pass, expr)
+			if iface != "" && settings.wellKnownMethods == methodModeRelaxed && expr.Doc == nil {
+				// Methods such as String are described by the interface they implement.
+				return
+			}
 
-				if literal, ok := be.X.(*ast.BasicLit); ok {
-					pass.Reportf(literal.Pos(), "literal found in conditional")
+			if expr.Doc == nil {
+				data := stubData{Name: expr.Name.Name, Kind: kindFunction, Receiver: receiverTypeName(expr.Recv)}
+				if isTestHelper(pass, expr) {
+					l.reportMissingDoc(pass, RuleFunctionComment, expr.Name, expr.Pos(), data, "test helper \"%s\" has no comment associated with it", expr.Name.Name)
+				} else {
+					l.reportMissingDoc(pass, RuleFunctionComment, expr.Name, expr.Pos(), data, "function \"%s\" has no comment associated with it", expr.Name.Name)
 				}
+				return
+			}
+
+			if l.checkCgoExport(pass, expr) {
+				return
+			}
+
+			if directive := onlyDirective(expr.Doc); directive != "" {
+				l.reportRange(pass, RuleFunctionComment, expr.Doc, "function \"%s\" has no comment associated with it, only the directive \"%s\", which is not documentation", expr.Name.Name, directive)
+				return
+			}
+
+			what := fmt.Sprintf("function \"%s\"", expr.Name.Name)
+			l.checkDoc(pass, kindFunction, what, expr.Name.Name, expr.Pos(), expr.Doc)
+
+			// The checks below expect the comment to begin with the name.
+			text := expr.Doc.Text()
+			if !strings.HasPrefix(strings.TrimSpace(text), expr.Name.Name) {
+				l.reportPrefix(pass, RuleFunctionComment, expr.Name, expr.Doc, expr.Name.Name, "comment for function \"%s\" should begin with \"%s\"", expr.Name.Name, expr.Name.Name)
+				return
+			}
+
+			if iface != "" && settings.wellKnownMethods == methodModeImplements {
+				l.checkWellKnownMethod(pass, expr, iface)
+			}
+			l.checkVerb(pass, expr)
+			l.checkComplexity(pass, expr)
+			l.checkFailureModes(pass, expr)
+			l.checkConstructor(pass, expr)
+			l.checkRestated(pass, what, expr.Name.Name, expr.Pos(), expr.Doc, expr)
+			l.checkParamReferences(pass, expr)
+			if l.checkStaleRefs {
+				l.checkStaleReferences(pass, what, expr.Pos(), expr.Doc, funcLocalNames(expr), receiverType(pass, expr))
+			}
+			l.checkTypeParams(pass, what, expr.Pos(), expr.Type.TypeParams, expr.Doc)
 
-				if literal, ok := be.Y.(*ast.BasicLit); ok {
-					pass.Reportf(literal.Pos(), "literal found in conditional")
+			if l.requireReceiverMention && expr.Recv != nil {
+				receiver := receiverTypeName(expr.Recv)
+				if receiver != "" && !containsWord(firstSentence(text), receiver) {
+					l.report(pass, RuleMethodReceiver, expr.Pos(), "comment for method \"%s\" should mention its receiver type \"%s\" in the first sentence", expr.Name.Name, receiver)
 				}
-			case *ast.GenDecl:
-				if expr.Tok == token.CONST {
-					if expr.Lparen.IsValid() {
-						// Constant block
-						if expr.Doc == nil {
-							pass.Reportf(expr.Pos(), "constant block has no comment associated with it")
-						}
-					}
+			}
+		case *ast.IfStmt:
+			l.checkConditionLiterals(pass, expr.Cond)
+		case *ast.SwitchStmt:
+			l.checkSwitchLiterals(pass, expr)
+		case *ast.CallExpr:
+			l.checkCallLiterals(pass, expr)
+		case *ast.GenDecl:
+			if expr.Tok == token.CONST || expr.Tok == token.VAR {
+				l.checkBlockGrouping(pass, file, settings.groupBlocks, expr)
+			}
 
-					for i := range expr.Specs {
-						vs, ok := expr.Specs[i].(*ast.ValueSpec)
-						if ok {
-							if len(vs.Names) > 1 {
-								var names []string
-								for j := range vs.Names {
-									names = append(names, vs.Names[j].Name)
-								}
-
-								pass.Reportf(vs.Pos(), "constants \"%s\" should be separated and each have a comment associated with them", strings.Join(names, ", "))
-								continue
-							}
+			if expr.Tok == token.CONST {
+				if expr.Lparen.IsValid() {
+					// Constant block
+					if expr.Doc == nil {
+						l.reportRange(pass, RuleConstantBlockComment, keyword(expr), "constant block has no comment associated with it")
+					} else if directive := onlyDirective(expr.Doc); directive != "" {
+						l.reportRange(pass, RuleConstantBlockComment, expr.Doc, "constant block has no comment associated with it, only the directive \"%s\", which is not documentation", directive)
+					}
 
-							name := vs.Names[0].Name
+					l.checkDoc(pass, kindConstant, "constant block", "", expr.Pos(), expr.Doc)
+				}
 
-							doc := vs.Doc
-							if !expr.Lparen.IsValid() {
-								// If this constant isn't apart of a constant block it's comment is stored in the *ast.GenDecl type.
-								doc = expr.Doc
-							}
+				// In relaxed mode, the members of an enum other than the first don't
+				// need comments if the block and the first member have one.
+				relaxedEnum := false
+				if isIotaBlock(pass, expr) {
+					l.checkEnumComment(pass, expr)
+					relaxedEnum = settings.iotaEnums == blockModeRelaxed && enumMembersDocumented(expr)
+				}
 
-							if doc == nil {
-								pass.Reportf(vs.Pos(), "constant \"%s\" has no comment associated with it", name)
-								continue
+				for i := range expr.Specs {
+					vs, ok := expr.Specs[i].(*ast.ValueSpec)
+					if ok {
+						if len(vs.Names) > 1 {
+							var names []string
+							for j := range vs.Names {
+								names = append(names, vs.Names[j].Name)
 							}
 
-							if !strings.HasPrefix(strings.TrimSpace(doc.Text()), name) {
-								pass.Reportf(vs.Pos(), "comment for constant \"%s\" should begin with \"%s\"", name, name)
-							}
+							l.reportRange(pass, RuleConstantComment, span{vs.Names[0].Pos(), vs.Names[len(vs.Names)-1].End()}, "constants \"%s\" should be separated and each have a comment associated with them", strings.Join(names, ", "))
+							continue
 						}
-					}
-				} else if expr.Tok == token.TYPE {
-					if expr.Lparen.IsValid() {
-						// Type block
-						if expr.Doc == nil {
-							pass.Reportf(expr.Pos(), "type block has no comment associated with it")
+
+						name := vs.Names[0].Name
+
+						doc := vs.Doc
+						if !expr.Lparen.IsValid() {
+							// If this constant isn't apart of a constant block it's comment is stored in the *ast.GenDecl type.
+							doc = expr.Doc
 						}
-					}
 
-					for i := range expr.Specs {
-						ts, ok := expr.Specs[i].(*ast.TypeSpec)
-						if ok {
-							doc := ts.Doc
-							if !expr.Lparen.IsValid() {
-								// If this type isn't apart of a type block it's comment is stored in the *ast.GenDecl type.
-								doc = expr.Doc
+						if doc == nil {
+							if !relaxedEnum || i == 0 {
+								l.reportMissingDoc(pass, RuleConstantComment, vs.Names[0], declStart(expr, vs), stubData{Name: name, Kind: kindConstant}, "constant \"%s\" has no comment associated with it", name)
 							}
+							continue
+						}
 
-							if doc == nil {
-								pass.Reportf(ts.Pos(), "type \"%s\" has no comment associated with it", ts.Name.Name)
-								continue
-							}
+						if directive := onlyDirective(doc); directive != "" {
+							l.reportRange(pass, RuleConstantComment, doc, "constant \"%s\" has no comment associated with it, only the directive \"%s\", which is not documentation", name, directive)
+							continue
+						}
 
-							if !strings.HasPrefix(strings.TrimSpace(doc.Text()), ts.Name.Name) {
-								pass.Reportf(ts.Pos(), "comment for type \"%s\" should begin with \"%s\"", ts.Name.Name, ts.Name.Name)
-							}
+						if !strings.HasPrefix(strings.TrimSpace(doc.Text()), name) {
+							l.reportPrefix(pass, RuleConstantComment, vs.Names[0], doc, name, "comment for constant \"%s\" should begin with \"%s\"", name, name)
 						}
+
+						l.checkDoc(pass, kindConstant, fmt.Sprintf("constant \"%s\"", name), name, vs.Pos(), doc)
 					}
 				}
-			default:
-				return true
+			} else if expr.Tok == token.TYPE {
+				l.checkTypeDecl(pass, settings, expr)
 			}
+		}
+	})
 
-			return true
-		})
-	}
+	l.checkDuplicatePackageComments(pass, filename)
+	l.checkExamples(pass, settings.examples)
+	l.checkAccessors(pass)
+	l.checkDuplicateComments(pass)
+	l.checkReadme(pass)
+	l.checkUpstreamDocs(pass)
 
-	for pkg := range packageWithSameNameFile {
-		if !packageWithSameNameFile[pkg] {
-			pass.Reportf(0, "package \"%s\" has no file with the same name containing package comment", pkg)
+	if checkPackageDoc && !hasPackageFile {
+		if misplaced := misplacedPackageComment(pass, filename); misplaced != "" {
+			l.report(pass, RulePackageFile, packagePos(pass, filename), "package \"%s\" has no file \"%s\" containing package comment, move the comment found in \"%s\" to it", pass.Pkg.Name(), filename, misplaced)
+		} else {
+			l.report(pass, RulePackageFile, packagePos(pass, filename), "package \"%s\" has no file \"%s\" containing package comment", pass.Pkg.Name(), filename)
 		}
 	}
 
-	return nil, nil
+	return result, nil
 }
 
-// validatePackageName ensures that a given package name follows the conventions that can
-// be read about here: https://blog.golang.org/package-names
-func validatePackageName(pkg string) string {
-	if strings.ContainsAny(pkg, "_-") {
-		return fmt.Sprintf("package \"%s\" should not contain - or _ in name", pkg)
+// report reports a diagnostic for the given rule at pos, unless the confidence of the
+// rule is below minConfidence. The ID of the rule is used as the diagnostic category.
+func (l *Linter) report(pass *analysis.Pass, rule Rule, pos token.Pos, format string, args ...interface{}) {
+	l.reportDiagnostic(pass, rule, analysis.Diagnostic{
+		Pos:     pos,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// reportRange reports a diagnostic for the given rule spanning rng, such as the
+// identifier missing a comment or the comment in question, so that editors can
+// underline it precisely.
+func (l *Linter) reportRange(pass *analysis.Pass, rule Rule, rng analysis.Range, format string, args ...interface{}) {
+	l.reportDiagnostic(pass, rule, analysis.Diagnostic{
+		Pos:     rng.Pos(),
+		End:     rng.End(),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// reportPrefix reports a diagnostic for the given rule spanning name, the identifier of
+// a declaration whose comment doc does not begin with expected, with related
+// information pointing at doc so that tools can show both the declaration and the
+// comment, and a fix beginning the comment with expected, as prefixFix does. No
+// fix is suggested if expected is empty.
+func (l *Linter) reportPrefix(pass *analysis.Pass, rule Rule, name *ast.Ident, doc *ast.CommentGroup, expected, format string, args ...interface{}) {
+	diag := analysis.Diagnostic{
+		Pos:     name.Pos(),
+		End:     name.End(),
+		Message: fmt.Sprintf(format, args...),
+		Related: []analysis.RelatedInformation{{
+			Pos:     doc.Pos(),
+			End:     doc.End(),
+			Message: fmt.Sprintf("comment of \"%s\" found here", name.Name),
+		}},
 	}
 
-	if pkg != strings.ToLower(pkg) {
-		return fmt.Sprintf("package \"%s\" should be all lowercase", pkg)
+	if fix, ok := prefixFix(doc, expected); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+	}
+
+	l.reportDiagnostic(pass, rule, diag)
+}
+
+// span is an analysis.Range between two positions, for ranges not covered by a single
+// node.
+type span struct {
+	pos, end token.Pos
+}
+
+// Pos returns the start of s.
+func (s span) Pos() token.Pos {
+	return s.pos
+}
+
+// End returns the end of s.
+func (s span) End() token.Pos {
+	return s.end
+}
+
+// keyword returns the range of the keyword of decl, such as const or type.
+func keyword(decl *ast.GenDecl) span {
+	return span{decl.TokPos, decl.TokPos + token.Pos(len(decl.Tok.String()))}
+}
+
+// reportDiagnostic reports diag for the given rule, unless the confidence of the rule
+// is below minConfidence. The category of diag is set to the ID of the rule, and its
+// URL to the documentation of the rule.
+func (l *Linter) reportDiagnostic(pass *analysis.Pass, rule Rule, diag analysis.Diagnostic) {
+	if rule.Confidence < l.minConfidence {
+		return
+	}
+
+	diag.Category = rule.ID
+	diag.URL = rule.URL()
+	pass.Report(diag)
+}
+
+// checkDuplicatePackageComments reports the files with a package comment, other than
+// the one holding the canonical comment, when more than one file of the package has a
+// package comment, since godoc concatenates them in an unspecified order. The canonical
+// comment is the one in the file with the given name or, if it has none, the first one
+// found. Test files are ignored, as godoc does.
+func (l *Linter) checkDuplicatePackageComments(pass *analysis.Pass, filename string) {
+	var documented []*ast.File
+	canonical := -1
+	for _, file := range pass.Files {
+		name := filepath.Base(pass.Fset.Position(file.Package).Filename)
+		if file.Doc == nil || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		if name == filename {
+			canonical = len(documented)
+		}
+		documented = append(documented, file)
+	}
+
+	if len(documented) < 2 {
+		return
+	}
+
+	if canonical < 0 {
+		canonical = 0
+	}
+	canonicalName := filepath.Base(pass.Fset.Position(documented[canonical].Package).Filename)
+
+	for i, file := range documented {
+		if i == canonical {
+			continue
+		}
+
+		l.report(pass, RuleDuplicatePackageComment, file.Package, "package \"%s\" already has a comment in \"%s\", godoc concatenates package comments from multiple files in an unspecified order", pass.Pkg.Name(), canonicalName)
+	}
+}
+
+// misplacedPackageComment returns the name of the first file with a package comment
+// other than the file with the given name, which must be the only file holding the
+// package comment, or an empty string if there is none. Test files are ignored, as
+// godoc does.
+func misplacedPackageComment(pass *analysis.Pass, filename string) string {
+	for _, file := range pass.Files {
+		name := filepath.Base(pass.Fset.Position(file.Package).Filename)
+		if file.Doc != nil && name != filename && !strings.HasSuffix(name, "_test.go") {
+			return name
+		}
 	}
 
 	return ""
 }
+
+// packagePos returns the position at which the findings for the package analyzed by
+// pass as a whole are reported: the package clause of the file with the given name,
+// which holds the package comment, falling back to the file holding a misplaced package
+// comment and then to the first non-test file of the package.
+func packagePos(pass *analysis.Pass, filename string) token.Pos {
+	if len(pass.Files) == 0 {
+		return token.NoPos
+	}
+
+	misplaced := misplacedPackageComment(pass, filename)
+	for _, name := range []string{filename, misplaced} {
+		for _, file := range pass.Files {
+			if name != "" && filepath.Base(pass.Fset.Position(file.Package).Filename) == name {
+				return file.Package
+			}
+		}
+	}
+
+	for _, file := range pass.Files {
+		if !strings.HasSuffix(pass.Fset.Position(file.Package).Filename, "_test.go") {
+			return file.Package
+		}
+	}
+
+	return pass.Files[0].Package
+}
+
+// onlyTestFiles reports whether every file of the package analyzed by pass is a test
+// file, as is the case for external test packages.
+func onlyTestFiles(pass *analysis.Pass) bool {
+	for _, file := range pass.Files {
+		if !strings.HasSuffix(pass.Fset.Position(file.Package).Filename, "_test.go") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// receiverType returns the type of the receiver of the method fn, or nil if fn is not a
+// method or its type is unknown.
+func receiverType(pass *analysis.Pass, fn *ast.FuncDecl) types.Type {
+	obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func)
+	if !ok || obj.Signature().Recv() == nil {
+		return nil
+	}
+
+	return obj.Signature().Recv().Type()
+}
+
+// receiverTypeName returns the name of the type of the given method receiver, without
+// any pointer or type parameters, or an empty string if it cannot be determined.
+func receiverTypeName(recv *ast.FieldList) string {
+	if recv == nil || len(recv.List) == 0 {
+		return ""
+	}
+
+	expr := recv.List[0].Type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ParenExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
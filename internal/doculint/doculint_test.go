@@ -0,0 +1,239 @@
+package doculint
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// ruleTests are the packages of testdata/src exercising each rule, with the flags they
+// are analyzed with. The findings expected in their files are given by want comments,
+// and the files for which fixes are suggested have a golden file holding them applied.
+var ruleTests = []struct {
+	rule  Rule
+	pkg   string
+	flags map[string]string
+}{
+	{RulePackageName, "packagename", nil},
+	{RulePackageFile, "packagefile", nil},
+	{RulePackageComment, "packagecomment/...", nil},
+	{RuleFunctionComment, "functioncomment", nil},
+	{RuleConstantBlockComment, "constantblockcomment", nil},
+	{RuleConstantComment, "constantcomment", nil},
+	{RuleTypeBlockComment, "typeblockcomment", nil},
+	{RuleTypeComment, "typecomment", nil},
+	{RuleConditionalLiteral, "conditionalliteral", nil},
+	{RuleMethodReceiver, "methodreceiver", map[string]string{"receiver-mention": "true"}},
+	{RuleCommentPeriod, "commentperiod", map[string]string{"period": "all"}},
+	{RuleProcessExit, "processexit", map[string]string{"exit-calls": "true"}},
+	{RuleExitComment, "exitcomment", map[string]string{"exit-docs": "true"}},
+	{RuleCommentSentence, "commentsentence", map[string]string{"sentence": "all"}},
+	{RuleDeprecated, "deprecated", nil},
+	{RuleDocLink, "doclink", nil},
+	{RuleLineLength, "linelength", map[string]string{"line-length": "80", "rewrap": "true"}},
+	{RuleCommentMarker, "commentmarker", nil},
+	{RulePackageCommentLength, "packagecommentlength", map[string]string{"package-words": "10"}},
+	{RuleDuplicatePackageComment, "duplicatepackagecomment", nil},
+	{RuleBlockGrouping, "blockgrouping", map[string]string{"group-blocks": "2"}},
+	{RuleExample, "example", map[string]string{"examples": "2"}},
+	{RuleBannedPhrase, "bannedphrase", nil},
+	{RuleErrorSentinel, "errorsentinel", map[string]string{"error-sentinels": "true"}},
+	{RuleIotaEnum, "iotaenum", map[string]string{"iota-enums": "relaxed"}},
+	{RuleFunctionVerb, "functionverb", map[string]string{"verbs": "true"}},
+	{RuleSpelling, "spelling", map[string]string{"spelling": "true"}},
+	{RuleLineComment, "linecomment", map[string]string{"line-comments": "true"}},
+	{RuleDetachedComment, "detachedcomment", nil},
+	{RuleParamReference, "paramreference", map[string]string{"params": "true"}},
+	{RulePanicComment, "paniccomment", map[string]string{"panic-docs": "true"}},
+	{RuleTypeParamComment, "typeparamcomment", map[string]string{"type-params": "true"}},
+	{RuleEmbeddedComment, "embeddedcomment", map[string]string{"embedded-docs": "true"}},
+	{RuleTypeAlias, "typealias", nil},
+	{RuleCgoExport, "cgoexport", nil},
+	{RuleGenerateComment, "generatecomment", map[string]string{"generate-docs": "true"}},
+	{RuleBuildConstraint, "buildconstraintcomment", map[string]string{"build-constraint-docs": "true"}},
+	{RuleStutter, "stutter", nil},
+	{RulePackageNameQuality, "packagenamequality", nil},
+	{RulePackageNamePlural, "packagenameplural", map[string]string{"plural-package-names": "true"}},
+	{RuleStdlibShadow, "stdlibshadow", nil},
+	{RuleTestFunctionComment, "testfunctioncomment", map[string]string{"benchmark-docs": "true", "fuzz-docs": "true"}},
+	{RuleMultiSentence, "multisentence", map[string]string{"multi-sentence-params": "4"}},
+	{RuleRestatedComment, "restatedcomment", map[string]string{"restated-docs": "true"}},
+	{RuleDocSyntax, "docsyntax", nil},
+	{RuleDocBlock, "docblock", nil},
+	{RuleMagicLiteral, "magicliteral", map[string]string{"call-literals": "true", "return-literals": "true"}},
+	{RuleNolintReason, "nolintreason", map[string]string{"nolint-reasons": "true"}},
+	{RuleConfigField, "configfield", map[string]string{"config-fields": "true"}},
+	{RuleDocLanguage, "doclanguage", map[string]string{"language": "en"}},
+	{RuleGlossary, "glossary", map[string]string{"config": "testdata/src/glossary/doculint.json"}},
+	{RuleDocURL, "docurl", nil},
+	{RuleFileHeader, "fileheader", map[string]string{"header": "testdata/src/fileheader/header.txt"}},
+	{RuleErrorComment, "errorcomment", map[string]string{"error-docs": "true"}},
+	{RuleConstructorComment, "constructorcomment", map[string]string{"constructor-docs": "true"}},
+	{RuleAccessorComment, "accessorcomment", map[string]string{"accessor-docs": "true", "accessor-links": "true"}},
+	{RuleInterfaceContract, "interfacecontract", map[string]string{"interface-sentences": "2"}},
+	{RuleDuplicateComment, "duplicatecomment", map[string]string{"duplicate-docs": "true"}},
+	{RuleStaleReference, "stalereference", map[string]string{"stale-refs": "true"}},
+	{RuleWellKnownMethod, "wellknownmethod", map[string]string{"well-known-methods": "implements"}},
+	{RuleTaggedFieldComment, "taggedfieldcomment", map[string]string{"tagged-field-docs": "json"}},
+	{RuleUpstreamComment, "upstreamcomment", map[string]string{"upstream-docs": "true"}},
+}
+
+// TestAnalyzer runs the analyzer on the package of every rule test, verifying the
+// findings it reports and the result of applying the fixes it suggests.
+func TestAnalyzer(t *testing.T) {
+	for _, test := range ruleTests {
+		t.Run(test.rule.ID, func(t *testing.T) {
+			l := newLinter(t, test.flags)
+			analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), l.Analyzer, test.pkg)
+		})
+	}
+}
+
+// TestReadme runs the analyzer on the package of the readme rule, whose findings are
+// positioned in its README rather than in its Go files, out of the reach of want
+// comments. They are compared to the findings expected instead.
+func TestReadme(t *testing.T) {
+	l := newLinter(t, map[string]string{"readme": "README.md"})
+
+	var rec recorder
+	results := analysistest.Run(&rec, analysistest.TestData(), l.Analyzer, "readme")
+	for _, err := range rec.errs {
+		if !strings.Contains(err, "README.md:") {
+			t.Error(err)
+		}
+	}
+
+	want := []string{
+		`README.md:3: README.md refers to "readme.RenderAll" which is not declared in package "readme"`,
+		`README.md:6: README.md refers to "readme.Paint" which has no comment associated with it`,
+	}
+
+	var got []string
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			posn := result.Pass.Fset.Position(diag.Pos)
+			if filepath.Base(posn.Filename) == "README.md" {
+				got = append(got, fmt.Sprintf("README.md:%d: %s", posn.Line, diag.Message))
+			}
+		}
+	}
+
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("got findings\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// TestScriptRules runs the analyzer with the rules defined in the configuration file of
+// the scriptrule package on it.
+func TestScriptRules(t *testing.T) {
+	l := newLinter(t, map[string]string{"config": "testdata/src/scriptrule/doculint.json"})
+	analysistest.Run(t, analysistest.TestData(), l.Analyzer, "scriptrule")
+}
+
+// TestCompileScriptRule verifies that the expressions of rules defined in the
+// configuration file are rejected when they are not valid CEL evaluating to a bool.
+func TestCompileScriptRule(t *testing.T) {
+	for _, test := range []struct {
+		report string
+		err    string
+	}{
+		{`exported && doc.startsWith("Deprecated:")`, ""},
+		{`name.upperAscii() == name && size(name) > 1`, ""},
+		{`size(doc)`, "expected bool"},
+		{`owner == "me"`, "undeclared reference to 'owner'"},
+		{`exported && `, "Syntax error"},
+		{`name + 1 == "a"`, "no matching overload"},
+		{`doc.matches("(")`, "missing closing )"},
+	} {
+		_, err := compileScriptRule(scriptRule{ID: "ORG001", Report: test.report})
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("compile %q: %v", test.report, err)
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("compile %q: got error %v, want an error containing %q", test.report, err, test.err)
+		}
+	}
+}
+
+// recorder is an analysistest.Testing recording the errors reported to it.
+type recorder struct {
+	errs []string
+}
+
+// Errorf records the error formatted from format and args.
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+// TestRulesHaveTests verifies that every rule of the analyzer has a rule test.
+func TestRulesHaveTests(t *testing.T) {
+	tested := map[string]bool{RuleReadme.ID: true}
+	for _, test := range ruleTests {
+		tested[test.rule.ID] = true
+	}
+
+	for _, rule := range builtinRules() {
+		if !tested[rule.ID] {
+			t.Errorf("rule %s %s has no rule test", rule.ID, rule.Name)
+		}
+	}
+}
+
+// TestLintersShareNoSettings verifies that the flags of a linter, and the files they
+// name, leave the findings of the other linters of the process unchanged.
+func TestLintersShareNoSettings(t *testing.T) {
+	src := "// Package p holds hosts.\npackage p\n\n// Allowed reports whether host is on the whitelist\nfunc Allowed(host string) bool { return false }\n"
+
+	strict := newLinter(t, map[string]string{"period": "all", "config": "testdata/src/glossary/doculint.json"})
+	relaxed := New()
+
+	for _, test := range []struct {
+		name   string
+		linter *Linter
+		want   []string
+	}{
+		{"strict", strict, []string{RuleCommentPeriod.ID, RuleGlossary.ID}},
+		{"relaxed", relaxed, nil},
+	} {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		diags, err := analyzeFile(test.linter, fset, file, []byte(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+		for _, diag := range diags {
+			got = append(got, diag.Category)
+		}
+		slices.Sort(got)
+
+		if !slices.Equal(got, test.want) {
+			t.Errorf("%s linter reported %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+// newLinter returns a new linter whose flags named by the keys of flags are set to their
+// values.
+func newLinter(tb testing.TB, flags map[string]string) *Linter {
+	l := New()
+	for name, value := range flags {
+		if err := l.Analyzer.Flags.Set(name, value); err != nil {
+			tb.Fatalf("set -%s: %v", name, err)
+		}
+	}
+
+	return l
+}
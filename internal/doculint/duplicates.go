@@ -0,0 +1,98 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// minDuplicateWords is the minimum number of words, besides the name of their
+// declaration, comments must have to be reported as duplicates, since short comments
+// such as "is a constant" are legitimately shared.
+const minDuplicateWords = 4
+
+// documentedDecl is a declaration with a doc comment.
+type documentedDecl struct {
+	// what describes the declaration, such as `function "Foo"`.
+	what string
+
+	// name is the name of the declaration.
+	name string
+
+	// pos is the position of the declaration.
+	pos token.Pos
+
+	// doc is the doc comment of the declaration.
+	doc *ast.CommentGroup
+}
+
+// checkDuplicateComments reports the declarations of the package analyzed by pass whose
+// doc comment is the same as that of a previous declaration, once the names of the
+// declarations beginning the comments are removed, when -duplicate-docs is set. Such
+// comments are almost always stale copies.
+func (l *Linter) checkDuplicateComments(pass *analysis.Pass) {
+	if !l.reportDuplicateDocs {
+		return
+	}
+
+	first := make(map[string]documentedDecl)
+	for _, file := range pass.Files {
+		for _, decl := range documentedDecls(file) {
+			text := strings.TrimPrefix(strings.TrimSpace(decl.doc.Text()), decl.name)
+			words := strings.Fields(strings.ToLower(text))
+			if len(words) < minDuplicateWords {
+				continue
+			}
+
+			key := strings.Join(words, " ")
+			original, ok := first[key]
+			if !ok {
+				first[key] = decl
+				continue
+			}
+
+			position := pass.Fset.Position(original.pos)
+			l.report(pass, RuleDuplicateComment, decl.pos, "comment for %s duplicates the comment for %s in \"%s\" at line %d, it is likely a stale copy", decl.what, original.what, filepath.Base(position.Filename), position.Line)
+		}
+	}
+}
+
+// documentedDecls returns the declarations of file that have a doc comment, in the
+// order they are declared.
+func documentedDecls(file *ast.File) []documentedDecl {
+	var decls []documentedDecl
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Doc != nil {
+				decls = append(decls, documentedDecl{what: "function \"" + decl.Name.Name + "\"", name: decl.Name.Name, pos: decl.Pos(), doc: decl.Doc})
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				doc := specDoc(spec)
+				if !decl.Lparen.IsValid() {
+					doc = decl.Doc
+				}
+
+				name := specName(spec)
+				if doc == nil || name == "" {
+					continue
+				}
+
+				what := decl.Tok.String()
+				if decl.Tok == token.CONST {
+					what = "constant"
+				} else if decl.Tok == token.VAR {
+					what = "variable"
+				}
+
+				decls = append(decls, documentedDecl{what: what + " \"" + name + "\"", name: name, pos: spec.Pos(), doc: doc})
+			}
+		}
+	}
+
+	return decls
+}
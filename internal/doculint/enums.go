@@ -0,0 +1,80 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// isIotaBlock reports whether decl is a constant block using iota, which is how enums
+// are declared.
+func isIotaBlock(pass *analysis.Pass, decl *ast.GenDecl) bool {
+	if !decl.Lparen.IsValid() {
+		return false
+	}
+
+	iota := types.Universe.Lookup("iota")
+
+	found := false
+	for _, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+
+		for _, value := range vs.Values {
+			ast.Inspect(value, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[id] == iota {
+					found = true
+				}
+				return !found
+			})
+		}
+	}
+
+	return found
+}
+
+// enumType returns the name of the type of the enum declared by the constant block
+// decl, which is the type of its first constant if it is a named type declared in the
+// analyzed package, or an empty string otherwise.
+func enumType(pass *analysis.Pass, decl *ast.GenDecl) string {
+	vs, ok := decl.Specs[0].(*ast.ValueSpec)
+	if !ok || len(vs.Names) == 0 {
+		return ""
+	}
+
+	obj := pass.TypesInfo.Defs[vs.Names[0]]
+	if obj == nil {
+		return ""
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok || named.Obj().Pkg() != pass.Pkg {
+		return ""
+	}
+
+	return named.Obj().Name()
+}
+
+// checkEnumComment reports the comment of the iota enum block decl if it does not
+// mention the type of the enum, since the block comment is where godoc readers look for
+// a description of the enum as a whole.
+func (l *Linter) checkEnumComment(pass *analysis.Pass, decl *ast.GenDecl) {
+	if decl.Doc == nil {
+		return
+	}
+
+	if typ := enumType(pass, decl); typ != "" && !containsWord(decl.Doc.Text(), typ) {
+		l.report(pass, RuleIotaEnum, decl.Pos(), "comment for enum block should describe the enum and mention its type \"%s\"", typ)
+	}
+}
+
+// enumMembersDocumented reports whether the iota enum block decl is documented well
+// enough for its members other than the first to not need comments in relaxed mode,
+// which is when both the block and its first member have a comment.
+func enumMembersDocumented(decl *ast.GenDecl) bool {
+	vs, ok := decl.Specs[0].(*ast.ValueSpec)
+	return decl.Doc != nil && ok && vs.Doc != nil
+}
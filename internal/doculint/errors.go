@@ -0,0 +1,86 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// errorSentinelPrefix is the prefix of the names of error sentinels, such as ErrNotFound.
+const errorSentinelPrefix = "Err"
+
+// errorType is the type of the predeclared error interface.
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// checkErrorSentinels validates the package-level variables of file named with the Err
+// prefix of error sentinels, which must be of error type and documented with a comment
+// of the form "ErrFoo is returned when ...", if -error-sentinels is set.
+func (l *Linter) checkErrorSentinels(pass *analysis.Pass, file *ast.File) {
+	if !l.checkSentinels {
+		return
+	}
+
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+
+			doc := vs.Doc
+			if !gd.Lparen.IsValid() {
+				// If this variable isn't apart of a block it's comment is stored in the *ast.GenDecl type.
+				doc = gd.Doc
+			}
+
+			for _, name := range vs.Names {
+				if isErrorSentinelName(name.Name) {
+					l.checkErrorSentinel(pass, name, doc)
+				}
+			}
+		}
+	}
+}
+
+// checkErrorSentinel validates the error sentinel declared by name and documented by
+// doc, which may be nil.
+func (l *Linter) checkErrorSentinel(pass *analysis.Pass, name *ast.Ident, doc *ast.CommentGroup) {
+	if obj := pass.TypesInfo.Defs[name]; obj != nil && !types.Implements(obj.Type(), errorType) {
+		l.reportRange(pass, RuleErrorSentinel, name, "variable \"%s\" is named like an error sentinel but is of type %s, which is not an error", name.Name, types.TypeString(obj.Type(), types.RelativeTo(pass.Pkg)))
+		return
+	}
+
+	if doc == nil || onlyDirective(doc) != "" {
+		l.reportRange(pass, RuleErrorSentinel, name, "error \"%s\" has no comment associated with it", name.Name)
+		return
+	}
+
+	if !strings.HasPrefix(strings.TrimSpace(doc.Text()), name.Name+" is returned ") {
+		l.reportPrefix(pass, RuleErrorSentinel, name, doc, "", "comment for error \"%s\" should be of the form \"%s is returned when ...\"", name.Name, name.Name)
+		return
+	}
+
+	l.checkDoc(pass, kindVariable, "error \""+name.Name+"\"", name.Name, name.Pos(), doc)
+}
+
+// isErrorSentinelName reports whether name is the name of an exported error sentinel,
+// which is the Err prefix followed by an upper case letter.
+func isErrorSentinelName(name string) bool {
+	rest, ok := strings.CutPrefix(name, errorSentinelPrefix)
+	if !ok {
+		return false
+	}
+
+	r, _ := utf8.DecodeRuneInString(rest)
+	return unicode.IsUpper(r)
+}
@@ -0,0 +1,124 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkExamples reports the exported functions and types of the package analyzed by
+// pass that have no Example function in the test files of the package directory, if
+// the package declares at least the given number of exported functions and types. A
+// threshold of 0 disables the check. Declarations in test files are ignored.
+func (l *Linter) checkExamples(pass *analysis.Pass, threshold int) {
+	if threshold <= 0 || pass.Pkg.Name() == "main" {
+		return
+	}
+
+	var decls []exportedDecl
+	for _, file := range pass.Files {
+		if strings.HasSuffix(pass.Fset.Position(file.Package).Filename, "_test.go") {
+			continue
+		}
+
+		decls = append(decls, exportedDecls(file)...)
+	}
+
+	if len(decls) == 0 || len(decls) < threshold {
+		return
+	}
+
+	dir := filepath.Dir(pass.Fset.Position(pass.Files[0].Package).Filename)
+	examples := exampleNames(dir, pass.Pkg.Name())
+
+	for _, decl := range decls {
+		if !examples[decl.name] {
+			l.report(pass, RuleExample, decl.pos, "exported %s \"%s\" has no example, add an Example%s function to the tests of package \"%s\"", decl.kind, decl.name, decl.name, pass.Pkg.Name())
+		}
+	}
+}
+
+// exportedDecl is an exported top-level function or type declaration.
+type exportedDecl struct {
+	// kind is the kind of the declaration, either "function" or "type".
+	kind string
+
+	// name is the name of the declared function or type.
+	name string
+
+	// pos is the position of the declaration.
+	pos token.Pos
+}
+
+// exportedDecls returns the exported top-level functions, excluding methods, and types
+// declared in file.
+func exportedDecls(file *ast.File) []exportedDecl {
+	var decls []exportedDecl
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil && decl.Name.IsExported() {
+				decls = append(decls, exportedDecl{"function", decl.Name.Name, decl.Pos()})
+			}
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range decl.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.IsExported() {
+					decls = append(decls, exportedDecl{"type", ts.Name.Name, ts.Pos()})
+				}
+			}
+		}
+	}
+
+	return decls
+}
+
+// exampleNames returns the names of the identifiers having an Example function in the
+// test files of the package with the given name in dir, including its external test
+// package. ExampleF_suffix is an example for F, and ExampleT_M for the type T. Files
+// that cannot be read or parsed are skipped.
+func exampleNames(dir, pkg string) map[string]bool {
+	names := make(map[string]bool)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return names
+	}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil || (file.Name.Name != pkg && file.Name.Name != pkg+"_test") {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil {
+				continue
+			}
+
+			name, ok := strings.CutPrefix(fn.Name.Name, "Example")
+			if !ok || name == "" {
+				continue
+			}
+
+			name, _, _ = strings.Cut(name, "_")
+			names[name] = true
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,105 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// exitFuncs maps the import path of a package to the functions and methods within it
+// that terminate the process.
+var exitFuncs = map[string][]string{
+	"os":  {"Exit"},
+	"log": {"Fatal", "Fatalf", "Fatalln"},
+}
+
+// terminationWords are the words, matched case insensitively as prefixes, that a doc
+// comment can use to document that a function terminates the process.
+var terminationWords = []string{"exit", "terminat", "fatal"}
+
+// checkExits reports calls within fn that terminate the process when the analyzed
+// package is not a main package. With -exit-calls every such call is reported, and
+// with -exit-docs the first such call is reported if the comment of fn does not
+// document that it terminates the process.
+func (l *Linter) checkExits(pass *analysis.Pass, fn *ast.FuncDecl) {
+	if pass.Pkg.Name() == "main" || fn.Body == nil || (!l.reportExitCalls && !l.requireExitDocs) {
+		return
+	}
+
+	documented := fn.Doc != nil && mentionsTermination(fn.Doc.Text())
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		name := exitFuncName(pass.TypesInfo, call)
+		if name == "" {
+			return true
+		}
+
+		if l.reportExitCalls {
+			l.report(pass, RuleProcessExit, call.Pos(), "call to %s terminates the process and should not be used in library package \"%s\"", name, pass.Pkg.Name())
+		}
+
+		if l.requireExitDocs && !documented {
+			l.report(pass, RuleExitComment, fn.Pos(), "comment for function \"%s\" should document that it terminates the process through %s", fn.Name.Name, name)
+			documented = true
+		}
+
+		return true
+	})
+}
+
+// exitFuncName returns the qualified name of the function called by call, such as
+// "os.Exit", if it terminates the process, or an empty string otherwise.
+func exitFuncName(info *types.Info, call *ast.CallExpr) string {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return ""
+	}
+
+	if contains(exitFuncs[fn.Pkg().Path()], fn.Name()) {
+		return fn.Pkg().Name() + "." + fn.Name()
+	}
+
+	return ""
+}
+
+// mentionsTermination reports whether text documents that the process is terminated,
+// that is whether one of its words begins with one of terminationWords.
+func mentionsTermination(text string) bool {
+	text = strings.ToLower(text)
+
+	for _, word := range terminationWords {
+		if hasWordWithPrefix(text, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasWordWithPrefix reports whether one of the words of text begins with prefix, so that
+// "exit" matches "exits" but not "nexit".
+func hasWordWithPrefix(text, prefix string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], prefix)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		if start == 0 || isNotWordRune(before) {
+			return true
+		}
+
+		offset = start + 1
+	}
+}
@@ -0,0 +1,168 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// docFact records whether an exported package-level declaration is documented, so that
+// the analysis of the packages importing it can tell, and so that drivers such as go
+// vet can cache it along with the results of the package.
+type docFact struct {
+	// Documented reports whether the declaration has a doc comment that is not only a
+	// directive.
+	Documented bool
+}
+
+// AFact marks docFact as an analysis.Fact.
+func (*docFact) AFact() {}
+
+// String returns documented or undocumented, as printed by drivers listing facts.
+func (f *docFact) String() string {
+	if f.Documented {
+		return "documented"
+	}
+
+	return "undocumented"
+}
+
+// exportDocFacts exports a docFact for every exported package-level declaration of the
+// package analyzed by pass, test files excluded since importers cannot see them. Facts
+// are only exported if -upstream-docs is set, since checkUpstreamDocs alone imports
+// them.
+func (l *Linter) exportDocFacts(pass *analysis.Pass) {
+	if !l.checkUpstream {
+		return
+	}
+
+	export := func(name *ast.Ident, doc *ast.CommentGroup) {
+		if !name.IsExported() {
+			return
+		}
+
+		if obj := pass.TypesInfo.Defs[name]; obj != nil {
+			pass.ExportObjectFact(obj, &docFact{Documented: doc != nil && onlyDirective(doc) == ""})
+		}
+	}
+
+	for _, file := range pass.Files {
+		if strings.HasSuffix(pass.Fset.Position(file.Package).Filename, "_test.go") {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					export(decl.Name, decl.Doc)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					doc := declDoc(decl, specDoc(spec))
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						export(spec.Name, doc)
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							export(name, doc)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// checkUpstreamDocs reports the exported declarations of the package analyzed by pass
+// that re-export or wrap an undocumented declaration of another package: type aliases,
+// variables and constants initialized with it, and functions whose body is a single
+// call to it. Whether the declarations of other packages are documented is only known
+// for packages analyzed from source, which excludes the dependencies loaded from
+// export data by the doculint command.
+func (l *Linter) checkUpstreamDocs(pass *analysis.Pass) {
+	if !l.checkUpstream {
+		return
+	}
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if !decl.Name.IsExported() || decl.Body == nil || len(decl.Body.List) != 1 {
+					continue
+				}
+
+				var call *ast.CallExpr
+				switch stmt := decl.Body.List[0].(type) {
+				case *ast.ReturnStmt:
+					if len(stmt.Results) == 1 {
+						call, _ = ast.Unparen(stmt.Results[0]).(*ast.CallExpr)
+					}
+				case *ast.ExprStmt:
+					call, _ = ast.Unparen(stmt.X).(*ast.CallExpr)
+				}
+
+				if call != nil {
+					if obj := undocumentedUpstream(pass, call.Fun); obj != nil {
+						l.reportRange(pass, RuleUpstreamComment, decl.Name, "function \"%s\" wraps \"%s.%s\", which has no comment associated with it", decl.Name.Name, obj.Pkg().Name(), obj.Name())
+					}
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						if !spec.Name.IsExported() || !spec.Assign.IsValid() {
+							continue
+						}
+
+						if obj := undocumentedUpstream(pass, spec.Type); obj != nil {
+							l.reportRange(pass, RuleUpstreamComment, spec.Name, "type \"%s\" re-exports \"%s.%s\", which has no comment associated with it", spec.Name.Name, obj.Pkg().Name(), obj.Name())
+						}
+					case *ast.ValueSpec:
+						for i, name := range spec.Names {
+							if !name.IsExported() || i >= len(spec.Values) {
+								continue
+							}
+
+							if obj := undocumentedUpstream(pass, spec.Values[i]); obj != nil {
+								l.reportRange(pass, RuleUpstreamComment, name, "\"%s\" re-exports \"%s.%s\", which has no comment associated with it", name.Name, obj.Pkg().Name(), obj.Name())
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// undocumentedUpstream returns the declaration of another package expr refers to, such
+// as pkg.Name or pkg.Name[T], if a docFact records that it is undocumented, or nil
+// otherwise.
+func undocumentedUpstream(pass *analysis.Pass, expr ast.Expr) types.Object {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.IndexExpr:
+		expr = e.X
+	case *ast.IndexListExpr:
+		expr = e.X
+	}
+
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	obj := pass.TypesInfo.Uses[sel.Sel]
+	if obj == nil || obj.Pkg() == nil || obj.Pkg() == pass.Pkg {
+		return nil
+	}
+
+	var fact docFact
+	if !pass.ImportObjectFact(obj, &fact) || fact.Documented {
+		return nil
+	}
+
+	return obj
+}
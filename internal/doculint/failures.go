@@ -0,0 +1,34 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkFailureModes reports the exported function fn, documented by a comment, if its
+// last result is an error and its comment does not match -error-doc-pattern, which by
+// default requires mentioning errors or failures, when -error-docs is set. Callers of
+// such functions need to know when they fail to handle the errors returned.
+func (l *Linter) checkFailureModes(pass *analysis.Pass, fn *ast.FuncDecl) {
+	if !l.requireErrorDocs || !fn.Name.IsExported() {
+		return
+	}
+
+	obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func)
+	if !ok {
+		return
+	}
+
+	results := obj.Signature().Results()
+	if results.Len() == 0 || !types.Identical(results.At(results.Len()-1).Type(), types.Universe.Lookup("error").Type()) {
+		return
+	}
+
+	if l.errorDocPattern.MatchString(fn.Doc.Text()) {
+		return
+	}
+
+	l.report(pass, RuleErrorComment, fn.Pos(), "comment for function \"%s\" should document when it returns an error", fn.Name.Name)
+}
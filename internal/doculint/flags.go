@@ -0,0 +1,563 @@
+package doculint
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Declaration kinds that rules can be configured for.
+const (
+	// kindPackage is the kind of package comments.
+	kindPackage = "package"
+
+	// kindFunction is the kind of function and method declarations.
+	kindFunction = "function"
+
+	// kindType is the kind of type declarations and type blocks.
+	kindType = "type"
+
+	// kindConstant is the kind of constant declarations and constant blocks.
+	kindConstant = "constant"
+
+	// kindVariable is the kind of variable declarations, such as error sentinels.
+	kindVariable = "variable"
+)
+
+// kinds is every declaration kind that can be used in a kindSet.
+var kinds = []string{kindPackage, kindFunction, kindType, kindConstant, kindVariable}
+
+// kindSet is a set of declaration kinds, used as a flag.Value for rules that are
+// configurable per declaration kind. It is set from a comma separated list of kinds,
+// or "all".
+type kindSet map[string]bool
+
+// String returns the kinds in the set as a sorted, comma separated list.
+func (ks kindSet) String() string {
+	var names []string
+	for kind := range ks {
+		names = append(names, kind)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ",")
+}
+
+// Set replaces the kinds in the set with the given comma separated list of kinds.
+func (ks kindSet) Set(s string) error {
+	for kind := range ks {
+		delete(ks, kind)
+	}
+
+	for _, kind := range strings.Split(s, ",") {
+		kind = strings.TrimSpace(kind)
+
+		switch {
+		case kind == "":
+			continue
+		case kind == "all":
+			for _, k := range kinds {
+				ks[k] = true
+			}
+		case contains(kinds, kind):
+			ks[kind] = true
+		default:
+			return fmt.Errorf("unknown declaration kind \"%s\", expected one of %s, or all", kind, strings.Join(kinds, ", "))
+		}
+	}
+
+	return nil
+}
+
+// stringList is a list of strings, used as a flag.Value for flags that take a comma
+// separated list of values.
+type stringList []string
+
+// String returns the strings in the list as a comma separated list.
+func (sl *stringList) String() string {
+	return strings.Join(*sl, ",")
+}
+
+// Set replaces the strings in the list with the given comma separated list, ignoring
+// empty values.
+func (sl *stringList) Set(s string) error {
+	*sl = nil
+
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			*sl = append(*sl, value)
+		}
+	}
+
+	return nil
+}
+
+// pattern is a regular expression, used as a flag.Value.
+type pattern struct {
+	*regexp.Regexp
+}
+
+// String returns the source text of the regular expression.
+func (p *pattern) String() string {
+	if p.Regexp == nil {
+		return ""
+	}
+
+	return p.Regexp.String()
+}
+
+// Set compiles the regular expression s.
+func (p *pattern) Set(s string) error {
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return err
+	}
+
+	p.Regexp = re
+	return nil
+}
+
+// contains reports whether list contains s.
+func contains(list []string, s string) bool {
+	for i := range list {
+		if list[i] == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Linter is a doculint analyzer along with its settings, set through the flags of the
+// analyzer, and the state built from them as packages are analyzed, such as the files
+// the flags name. Linters share none of it, so that the analyzers of a process, such
+// as those of nogo and of the library, can be configured differently.
+type Linter struct {
+	// Analyzer is the analyzer of the linter, configured through its flags.
+	Analyzer *analysis.Analyzer
+
+	// minConfidence is the minimum confidence a rule must have for its findings to be
+	// reported, configured through the -min-confidence flag.
+	minConfidence Confidence
+
+	// requireReceiverMention controls whether method comments must mention the receiver
+	// type in their first sentence, configured through the -receiver-mention flag.
+	requireReceiverMention bool
+
+	// requirePeriod is the set of declaration kinds whose comments must end with terminal
+	// punctuation, configured through the -period flag.
+	requirePeriod kindSet
+
+	// reportExitCalls controls whether calls that terminate the process are reported in
+	// library packages, configured through the -exit-calls flag.
+	reportExitCalls bool
+
+	// requireExitDocs controls whether functions in library packages that terminate the
+	// process must document it, configured through the -exit-docs flag.
+	requireExitDocs bool
+
+	// requireSentence is the set of declaration kinds whose comments must form at least
+	// one complete sentence, configured through the -sentence flag.
+	requireSentence kindSet
+
+	// checkDeprecation controls whether deprecation notices are validated, configured
+	// through the -deprecated flag.
+	checkDeprecation bool
+
+	// checkLinks controls whether doc links are validated, configured through the
+	// -doc-links flag.
+	checkLinks bool
+
+	// maxLineLength is the maximum number of characters a line of doc comment may have,
+	// configured through the -line-length flag. Zero disables the check.
+	maxLineLength int
+
+	// rewrapComments controls whether fixes rewrapping paragraphs with long lines are
+	// suggested, configured through the -rewrap flag.
+	rewrapComments bool
+
+	// todoMarkers are the markers, such as TODO, that are reported when found in the doc
+	// comments of exported declarations, configured through the -markers flag.
+	todoMarkers stringList
+
+	// configPath is the path of the configuration file, configured through the -config
+	// flag.
+	configPath string
+
+	// typeBlocks is the mode for how type blocks are documented, configured through the
+	// -type-blocks flag.
+	typeBlocks blockMode
+
+	// exemptSingleTypeBlocks controls whether type blocks containing a single type are
+	// treated as if the type was not in a block, configured through the
+	// -exempt-single-type-blocks flag.
+	exemptSingleTypeBlocks bool
+
+	// readmePath is the path, relative to the directory of each package, of the README
+	// whose references to the identifiers of the package are verified, configured through
+	// the -readme flag.
+	readmePath string
+
+	// minPackageWords is the minimum number of words a package comment must have,
+	// configured through the -package-words flag.
+	minPackageWords int
+
+	// minPackageSentences is the minimum number of sentences a package comment must have,
+	// configured through the -package-sentences flag.
+	minPackageSentences int
+
+	// packageFile is the name of the file that must contain the package comment, or empty
+	// for the file named after the package, configured through the -package-file flag.
+	packageFile string
+
+	// groupBlocks is the number of entries above which constant and variable blocks must
+	// be split into commented groups, or 0 to disable the check, configured through the
+	// -group-blocks flag.
+	groupBlocks int
+
+	// requireExamples is the number of exported functions and types from which a package
+	// must have an example for each of them, or 0 to disable the check, configured through
+	// the -examples flag.
+	requireExamples int
+
+	// bannedPhrases are the phrases, matched case insensitively, that are reported when
+	// found in doc comments, configured through the -banned-phrases flag.
+	bannedPhrases stringList
+
+	// checkSentinels controls whether error sentinels, package-level variables named like
+	// ErrNotFound, are validated, configured through the -error-sentinels flag.
+	checkSentinels bool
+
+	// iotaEnums is the mode for how the members of iota enum blocks are documented,
+	// configured through the -iota-enums flag.
+	iotaEnums blockMode
+
+	// requireVerbs controls whether function comments must continue with a present tense
+	// verb after the name of the function, configured through the -verbs flag.
+	requireVerbs bool
+
+	// checkSpell controls whether the comments of exported declarations are spellchecked,
+	// configured through the -spelling flag.
+	checkSpell bool
+
+	// dictionaryPath is the path to a file of words, one per line, known to the
+	// spellchecker in addition to its built-in words, configured through the -dictionary
+	// flag.
+	dictionaryPath string
+
+	// requireLineComments controls whether the doc comments of declarations other than
+	// packages must be line comments, configured through the -line-comments flag.
+	requireLineComments bool
+
+	// checkParams controls whether the identifiers referenced in function comments must be
+	// parameters, results, or receivers of the function, configured through the -params
+	// flag.
+	checkParams bool
+
+	// requirePanicDocs controls whether exported functions that call panic must document
+	// it, configured through the -panic-docs flag.
+	requirePanicDocs bool
+
+	// requireTypeParamDocs controls whether the comments of generic functions and types
+	// must mention each of their type parameters, configured through the -type-params
+	// flag.
+	requireTypeParamDocs bool
+
+	// requireEmbeddedDocs controls whether the fields embedded in exported structs must
+	// have comments, configured through the -embedded-docs flag.
+	requireEmbeddedDocs bool
+
+	// requireInitDocs controls whether init functions must have a comment explaining
+	// their side effects, configured through the -init-docs flag.
+	requireInitDocs bool
+
+	// requireGenerateDocs controls whether //go:generate directives must be preceded by a
+	// comment explaining them, configured through the -generate-docs flag.
+	requireGenerateDocs bool
+
+	// requireConstraintDocs controls whether files with build constraints must have a
+	// comment explaining them, configured through the -build-constraint-docs flag.
+	requireConstraintDocs bool
+
+	// checkStutters controls whether exported identifiers repeating the package name are
+	// reported, configured through the -stutter flag.
+	checkStutters bool
+
+	// maxPackageNameLength is the number of characters package names may not exceed, or 0
+	// to disable the check, configured through the -package-name-length flag.
+	maxPackageNameLength int
+
+	// genericPackageNames are the package names reported for saying nothing about what the
+	// package provides, configured through the -generic-package-names flag.
+	genericPackageNames stringList
+
+	// checkPluralPackageNames controls whether plural package names are reported, configured
+	// through the -plural-package-names flag.
+	checkPluralPackageNames bool
+
+	// checkStdlibShadows controls whether package names shadowing the standard library are
+	// reported, configured through the -stdlib-names flag.
+	checkStdlibShadows bool
+
+	// allowedStdlibNames are the standard library package names packages may nonetheless
+	// use, configured through the -allow-stdlib-names flag.
+	allowedStdlibNames stringList
+
+	// requireBenchmarkDocs controls whether benchmarks must have a comment describing the
+	// workload they measure, configured through the -benchmark-docs flag.
+	requireBenchmarkDocs bool
+
+	// requireFuzzDocs controls whether fuzz tests must have a comment describing the corpus
+	// they explore, configured through the -fuzz-docs flag.
+	requireFuzzDocs bool
+
+	// minComplexParams is the number of parameters from which the comments of exported
+	// functions need multiple sentences, or 0 to disable the check, configured through the
+	// -multi-sentence-params flag.
+	minComplexParams int
+
+	// minComplexLines is the number of lines from which the comments of exported functions
+	// need multiple sentences, or 0 to disable the check, configured through the
+	// -multi-sentence-lines flag.
+	minComplexLines int
+
+	// requireInformativeDocs controls whether comments restating the name and signature of
+	// their declaration are reported, configured through the -restated-docs flag.
+	requireInformativeDocs bool
+
+	// checkSyntax controls whether doc comments are validated against the go/doc/comment
+	// syntax, configured through the -doc-syntax flag.
+	checkSyntax bool
+
+	// reportCallLiterals controls whether numeric literals passed as function arguments are
+	// reported, configured through the -call-literals flag.
+	reportCallLiterals bool
+
+	// callLiteralExempt are the functions, such as "make" or "time.Sleep", whose arguments
+	// may be numeric literals, configured through the -call-literal-exempt flag.
+	callLiteralExempt stringList
+
+	// allowedLiterals are the literals, as written in the source, that may be used in
+	// conditionals, function arguments, and return values without being named, configured
+	// through the -allowed-literals flag.
+	allowedLiterals stringList
+
+	// literalThreshold is the absolute value below which numeric literals may be used
+	// without being named, or 0 to disable the threshold, configured through the
+	// -literal-threshold flag.
+	literalThreshold float64
+
+	// reportNumericLiterals controls whether the literal checks report numeric literals,
+	// configured through the -numeric-literals flag.
+	reportNumericLiterals bool
+
+	// reportStringLiterals controls whether the literal checks report string and rune
+	// literals, configured through the -string-literals flag.
+	reportStringLiterals bool
+
+	// reportReturnLiterals controls whether numeric literals returned by exported functions
+	// are reported, configured through the -return-literals flag.
+	reportReturnLiterals bool
+
+	// requireNolintReasons controls whether //nolint comments must explain why issues are
+	// suppressed, configured through the -nolint-reasons flag.
+	requireNolintReasons bool
+
+	// requireConfigFieldDocs controls whether the exported fields of configuration structs
+	// must document their default value, configured through the -config-fields flag.
+	requireConfigFieldDocs bool
+
+	// configSuffixes are the suffixes of the names of configuration structs, configured
+	// through the -config-suffixes flag.
+	configSuffixes stringList
+
+	// docLanguage is the language the comments of exported declarations must be written
+	// in, or empty to disable the check, configured through the -language flag.
+	docLanguage languageCode
+
+	// checkDocURLs controls whether the URLs in doc comments are validated, configured
+	// through the -doc-urls flag.
+	checkDocURLs bool
+
+	// checkURLReachability controls whether the URLs in doc comments must be reachable,
+	// configured through the -url-reachability flag.
+	checkURLReachability bool
+
+	// headerPath is the path of the template of the license or copyright header files must
+	// begin with, or empty to disable the check, configured through the -header flag.
+	headerPath string
+
+	// requireErrorDocs controls whether exported functions returning an error must
+	// document when they do, configured through the -error-docs flag.
+	requireErrorDocs bool
+
+	// errorDocPattern is the pattern the comments of exported functions returning an error
+	// must match, configured through the -error-doc-pattern flag.
+	errorDocPattern pattern
+
+	// requireConstructorDocs controls whether the comments of NewXxx constructors must
+	// mention the type they return, configured through the -constructor-docs flag.
+	requireConstructorDocs bool
+
+	// requireAccessorDocs controls whether the comments of paired getters and setters must
+	// do more than echo their names, configured through the -accessor-docs flag.
+	requireAccessorDocs bool
+
+	// requireAccessorLinks controls whether the comments of paired getters and setters must
+	// mention each other, configured through the -accessor-links flag.
+	requireAccessorLinks bool
+
+	// minInterfaceSentences is the number of sentences the comments of exported interfaces
+	// need, or 0 to disable the check, configured through the -interface-sentences flag.
+	minInterfaceSentences int
+
+	// reportDuplicateDocs controls whether declarations sharing the same doc comment are
+	// reported, configured through the -duplicate-docs flag.
+	reportDuplicateDocs bool
+
+	// checkStaleRefs controls whether the identifiers referenced in doc comments must be
+	// declared, configured through the -stale-refs flag.
+	checkStaleRefs bool
+
+	// wellKnownMethodDocs is the mode for how the methods implementing well-known
+	// interfaces are documented, configured through the -well-known-methods flag.
+	wellKnownMethodDocs methodMode
+
+	// dedupFindings controls whether findings repeated on the same line are reported once
+	// with their number of occurrences, configured through the -dedup flag.
+	dedupFindings bool
+
+	// taggedFieldKeys are the keys of the struct tags, such as json, whose fields must have
+	// comments, configured through the -tagged-field-docs flag.
+	taggedFieldKeys stringList
+
+	// checkUpstream controls whether exported declarations re-exporting or wrapping
+	// undocumented declarations of other packages are reported, configured through the
+	// -upstream-docs flag.
+	checkUpstream bool
+
+	// loaded guards the loading of the configuration file, which happens once for
+	// every package analyzed.
+	loaded struct {
+		once sync.Once
+		cfg  config
+		err  error
+	}
+
+	// checks are the checks run by the linter, set up once the configuration file is
+	// loaded.
+	checks struct {
+		once sync.Once
+		list []Check
+
+		// rules are the rules of the checks, keyed by ID.
+		rules map[string]Rule
+
+		// dispatcher dispatches the nodes of packages to the checks.
+		dispatcher *checkDispatcher
+		err        error
+	}
+
+	// header guards the loading of the -header template.
+	header struct {
+		once    sync.Once
+		text    string
+		pattern *regexp.Regexp
+		err     error
+	}
+
+	// dictionary guards the loading of the words known to the spellchecker, which are
+	// the built-in words and those of the -dictionary file.
+	dictionary struct {
+		once  sync.Once
+		words map[string]bool
+		err   error
+	}
+
+	// reachability caches the result of the requests checking the reachability of
+	// URLs, mapping each URL to an empty string if it is reachable or a description of
+	// why it is not, since the same URLs are commonly found across the packages of a
+	// module.
+	reachability sync.Map
+}
+
+// registerFlags registers the flags setting the fields of l on flags.
+func (l *Linter) registerFlags(flags *flag.FlagSet) {
+	flags.StringVar(&l.configPath, "config", "", "path to a JSON configuration file with per-package settings")
+	flags.Var(&l.minConfidence, "min-confidence", "only report findings from rules with at least this confidence (low, medium, or high)")
+	flags.BoolVar(&l.dedupFindings, "dedup", true, "report findings repeated by a rule on the same line once, with their number of occurrences")
+	flags.Var(&l.typeBlocks, "type-blocks", "whether both type blocks and the types in them need comments (strict), or either one (relaxed)")
+	flags.Var(&l.iotaEnums, "iota-enums", "whether every member of iota enum blocks needs a comment (strict), or only the first one when the block has a comment (relaxed)")
+	flags.Var(&l.wellKnownMethodDocs, "well-known-methods", "whether methods implementing well-known interfaces, such as String or MarshalJSON, need comments (strict), do not (relaxed), or need comments mentioning the interface (implements)")
+	flags.BoolVar(&l.exemptSingleTypeBlocks, "exempt-single-type-blocks", false, "treat type blocks containing a single type as if the type was not in a block")
+	flags.BoolVar(&l.requireReceiverMention, "receiver-mention", false, "require method comments to mention the receiver type in their first sentence")
+	flags.BoolVar(&l.requireNolintReasons, "nolint-reasons", false, "require //nolint comments to explain why issues are suppressed, as in //nolint:doculint // Generated by protoc.")
+	flags.BoolVar(&l.checkDeprecation, "deprecated", true, "validate that deprecation notices are paragraphs beginning with \"Deprecated: \"")
+	flags.BoolVar(&l.checkStutters, "stutter", true, "validate that exported identifiers do not repeat the package name, such as pkg.PkgClient")
+	flags.BoolVar(&l.checkSyntax, "doc-syntax", true, "validate that doc comments render as intended, reporting implicit headings, unclosed doc links, and comments not formatted as gofmt would")
+	flags.BoolVar(&l.checkLinks, "doc-links", true, "validate that doc links such as [Name] and [pkg.Name] resolve to declared identifiers")
+	flags.BoolVar(&l.checkDocURLs, "doc-urls", true, "validate that the URLs in doc comments are well formed, reporting those truncated by a line break")
+	flags.BoolVar(&l.checkURLReachability, "url-reachability", false, "validate that the http and https URLs in doc comments are reachable, which makes network requests")
+	flags.StringVar(&l.packageFile, "package-file", "", "name of the file that must contain the package comment, such as doc.go, or empty for the file named after the package")
+	flags.IntVar(&l.groupBlocks, "group-blocks", 0, "number of entries above which constant and variable blocks must be split into groups separated by blank lines, each introduced by a comment, or 0 to disable the check")
+	flags.IntVar(&l.requireExamples, "examples", 0, "number of exported functions and types from which a package must have an Example function for each of them, or 0 to disable the check")
+	flags.IntVar(&l.minPackageWords, "package-words", 0, "minimum number of words in a package comment, including \"Package <name>\"")
+	flags.IntVar(&l.minPackageSentences, "package-sentences", 0, "minimum number of sentences in a package comment")
+	flags.BoolVar(&l.checkSpell, "spelling", false, "report likely misspellings in the comments of exported declarations")
+	flags.Var(&l.docLanguage, "language", "ISO 639-1 code of the language the comments of exported declarations must be written in (en, de, es, fr, it, nl, or pt), or empty to disable the check")
+	flags.StringVar(&l.headerPath, "header", "", "path of a file holding the license or copyright header, comment markers included, that every file must begin with, where {{YEAR}} matches any year")
+	flags.StringVar(&l.dictionaryPath, "dictionary", "", "path to a file of words, one per line, known to the spellchecker in addition to its built-in words")
+	flags.IntVar(&l.maxPackageNameLength, "package-name-length", 0, "maximum number of characters in package names, 0 disables the check")
+	flags.Var(&l.genericPackageNames, "generic-package-names", "comma separated package names reported as meaningless, or empty to disable the check")
+	flags.BoolVar(&l.checkPluralPackageNames, "plural-package-names", false, "validate that package names are singular")
+	flags.BoolVar(&l.checkStdlibShadows, "stdlib-names", true, "validate that package names do not shadow popular standard library packages")
+	flags.Var(&l.allowedStdlibNames, "allow-stdlib-names", "comma separated standard library package names that packages may use")
+	flags.Var(&l.bannedPhrases, "banned-phrases", "comma separated phrases reported in doc comments, or empty to disable the check")
+	flags.Var(&l.todoMarkers, "markers", "comma separated markers reported in the comments of exported declarations, or empty to disable the check")
+	flags.IntVar(&l.maxLineLength, "line-length", 0, "maximum number of characters in a line of doc comment, such as 80 or 100, or 0 to disable the check")
+	flags.BoolVar(&l.rewrapComments, "rewrap", false, "suggest fixes that rewrap doc comment paragraphs exceeding -line-length")
+	flags.StringVar(&l.readmePath, "readme", "", "path of a README, relative to each package directory, whose references to identifiers of the package must exist and be documented")
+	flags.BoolVar(&l.checkSentinels, "error-sentinels", false, "require package-level variables named like ErrNotFound to be errors documented as \"ErrNotFound is returned when ...\"")
+	flags.BoolVar(&l.requireLineComments, "line-comments", false, "require the doc comments of declarations other than packages to be line comments (//) rather than block comments (/* */)")
+	flags.BoolVar(&l.checkParams, "params", false, "require the identifiers referenced in function comments as code or doc links to be parameters, results, or receivers of the function")
+	flags.BoolVar(&l.checkUpstream, "upstream-docs", false, "report exported type aliases, variables, constants, and wrapper functions re-exporting undocumented declarations of other packages analyzed from source")
+	flags.BoolVar(&l.checkStaleRefs, "stale-refs", false, "require the identifiers referenced in function and type comments, such as Client.Do, http.Handler, or parseHeader, to be declared")
+	flags.BoolVar(&l.requireTypeParamDocs, "type-params", false, "require the comments of generic functions and types to mention each of their type parameters")
+	flags.BoolVar(&l.requireEmbeddedDocs, "embedded-docs", false, "require the fields embedded in exported structs to have a comment explaining why they are embedded")
+	flags.BoolVar(&l.requireConfigFieldDocs, "config-fields", false, "require every exported field of exported structs named with a suffix in -config-suffixes to have a comment documenting its default value")
+	flags.Var(&l.configSuffixes, "config-suffixes", "comma separated suffixes of the names of configuration structs checked by -config-fields")
+	flags.Var(&l.taggedFieldKeys, "tagged-field-docs", "comma separated struct tag keys, such as json,yaml,xml, whose exported fields must have comments, or empty to disable the check")
+	flags.BoolVar(&l.requireInitDocs, "init-docs", false, "require init functions to have a comment explaining their side effects")
+	flags.BoolVar(&l.requireGenerateDocs, "generate-docs", false, "require //go:generate directives to be preceded by a comment explaining what they generate and how to regenerate it")
+	flags.IntVar(&l.minComplexParams, "multi-sentence-params", 0, "number of parameters from which exported functions need comments of at least two sentences, 0 disables the check")
+	flags.IntVar(&l.minComplexLines, "multi-sentence-lines", 0, "number of body lines from which exported functions need comments of at least two sentences, 0 disables the check")
+	flags.IntVar(&l.minInterfaceSentences, "interface-sentences", 0, "number of sentences the comments of exported interfaces need to describe the contract of their implementations, 0 disables the check")
+	flags.BoolVar(&l.reportDuplicateDocs, "duplicate-docs", false, "report declarations whose comment is the same as that of another declaration of the package, once their names are removed")
+	flags.BoolVar(&l.requireInformativeDocs, "restated-docs", false, "report comments that only restate the name and signature of their declaration, such as \"GetUser gets user\"")
+	flags.BoolVar(&l.reportCallLiterals, "call-literals", false, "report numeric literals passed as function arguments")
+	flags.Var(&l.callLiteralExempt, "call-literal-exempt", "comma separated functions, such as make, time.Sleep, or Builder.Grow, whose arguments may be numeric literals")
+	flags.BoolVar(&l.reportReturnLiterals, "return-literals", false, "report numeric literals returned by exported functions")
+	flags.Var(&l.allowedLiterals, "allowed-literals", "comma separated literals, as written in the source, that the literal checks never report")
+	flags.Float64Var(&l.literalThreshold, "literal-threshold", 0, "absolute value below which the literal checks do not report numeric literals, or 0 to disable the threshold")
+	flags.BoolVar(&l.reportNumericLiterals, "numeric-literals", true, "report numeric literals in the literal checks")
+	flags.BoolVar(&l.reportStringLiterals, "string-literals", true, "report string and rune literals in the literal checks")
+	flags.BoolVar(&l.requireBenchmarkDocs, "benchmark-docs", false, "require BenchmarkXxx functions to have a comment describing the workload they measure")
+	flags.BoolVar(&l.requireFuzzDocs, "fuzz-docs", false, "require FuzzXxx functions to have a comment describing the corpus they explore")
+	flags.BoolVar(&l.requireConstraintDocs, "build-constraint-docs", false, "require files with //go:build constraints to have a comment explaining why the constraint exists")
+	flags.BoolVar(&l.requireVerbs, "verbs", false, "require function comments to continue with a present tense verb after the name of the function, as in \"Foo returns\"")
+	flags.BoolVar(&l.requireErrorDocs, "error-docs", false, "require the comments of exported functions whose last result is an error to match -error-doc-pattern, documenting when they fail")
+	flags.Var(&l.errorDocPattern, "error-doc-pattern", "regular expression the comments of exported functions returning an error must match with -error-docs")
+	flags.BoolVar(&l.requireConstructorDocs, "constructor-docs", false, "require the comments of NewXxx constructors to mention the type they return, as in \"NewClient returns a Client\"")
+	flags.BoolVar(&l.requireAccessorDocs, "accessor-docs", false, "require the comments of paired Foo and SetFoo methods to do more than echo their names, as in \"SetFoo sets foo\"")
+	flags.BoolVar(&l.requireAccessorLinks, "accessor-links", false, "require the comments of paired Foo and SetFoo methods to mention each other, with -accessor-docs")
+	flags.BoolVar(&l.requirePanicDocs, "panic-docs", false, "require exported functions that call panic to mention that they panic in their comment")
+	flags.BoolVar(&l.reportExitCalls, "exit-calls", false, "report calls to os.Exit and log.Fatal in non-main packages")
+	flags.BoolVar(&l.requireExitDocs, "exit-docs", false, "require functions in non-main packages that call os.Exit or log.Fatal to document it")
+	flags.Var(l.requirePeriod, "period", "comma separated declaration kinds (package, function, type, constant, variable, or all) whose comments must end with a period")
+	flags.Var(l.requireSentence, "sentence", "comma separated declaration kinds (package, function, type, constant, variable, or all) whose comments must be complete sentences")
+}
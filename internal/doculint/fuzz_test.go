@@ -0,0 +1,176 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// fuzzSeeds are Go sources exercising unusual comment placements and declarations, used
+// to seed FuzzAnalyzer.
+var fuzzSeeds = []string{
+	"package p",
+	"// Package p is a package.\npackage p\n",
+	"package p\n\n/**/\nfunc F() {}\n",
+	"package p\n\n//\nfunc F() {}\n",
+	"package p\n\n/* F */ func F() {}\n",
+	"package p\n\n//go:noinline\nfunc F() {}\n",
+	"package p\n\n// F does [T.M] and [x.Y] and [p.Q.R].\n//\n// Deprecated: use G.\nfunc F() {}\n",
+	"package p\n\nconst (\n\t// A\n\tA, B = 1, 2\n\t_ = 3\n)\n",
+	"package p\n\ntype (\n)\n\nconst ()\n",
+	"package p\n\ntype T[P any, Q comparable] struct{}\n\nfunc (T[P, Q]) M() {}\n",
+	"package p\n\nimport \"os\"\n\nfunc F() { os.Exit(1) }\n",
+	"package p\n\nfunc F() {\n\tif 1 == 2 {\n\t}\n}\n",
+	"package p\n\n// " + strings.Repeat("word ", 100) + "\n//\n//\tcode\nfunc F() {}\n",
+	hugeConstBlock(500),
+}
+
+// hugeConstBlock returns the source of a package containing a single constant block
+// with n documented constants.
+func hugeConstBlock(n int) string {
+	var b strings.Builder
+	b.WriteString("package p\n\n// Constants.\nconst (\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "\t// C%d is a constant.\n\tC%d = %d\n", i, i, i)
+	}
+	b.WriteString(")\n")
+
+	return b.String()
+}
+
+// FuzzAnalyzer runs the analyzer, with every optional check enabled, on arbitrary Go
+// source to catch panics on malformed or exotic syntax trees. Sources that do not parse
+// are skipped, sources that do not type check are still analyzed.
+func FuzzAnalyzer(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	l := newLinter(f, map[string]string{
+		"receiver-mention":      "true",
+		"period":                "all",
+		"sentence":              "all",
+		"exit-calls":            "true",
+		"exit-docs":             "true",
+		"line-length":           "80",
+		"rewrap":                "true",
+		"group-blocks":          "2",
+		"examples":              "1",
+		"error-sentinels":       "true",
+		"iota-enums":            "relaxed",
+		"well-known-methods":    "implements",
+		"verbs":                 "true",
+		"spelling":              "true",
+		"line-comments":         "true",
+		"params":                "true",
+		"panic-docs":            "true",
+		"type-params":           "true",
+		"embedded-docs":         "true",
+		"init-docs":             "true",
+		"generate-docs":         "true",
+		"build-constraint-docs": "true",
+		"benchmark-docs":        "true",
+		"fuzz-docs":             "true",
+		"restated-docs":         "true",
+		"call-literals":         "true",
+		"return-literals":       "true",
+		"nolint-reasons":        "true",
+		"config-fields":         "true",
+		"tagged-field-docs":     "json,yaml",
+		"language":              "en",
+		"error-docs":            "true",
+		"constructor-docs":      "true",
+		"accessor-docs":         "true",
+		"accessor-links":        "true",
+		"duplicate-docs":        "true",
+		"stale-refs":            "true",
+		"upstream-docs":         "true",
+		"literal-threshold":     "10",
+		"multi-sentence-params": "3",
+		"multi-sentence-lines":  "10",
+		"interface-sentences":   "2",
+		"plural-package-names":  "true",
+		"package-name-length":   "8",
+	})
+
+	f.Fuzz(func(t *testing.T, src string) {
+		fset := token.NewFileSet()
+
+		file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+		if err != nil {
+			t.Skip()
+		}
+
+		if _, err := analyzeFile(l, fset, file, []byte(src)); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// analyzeFile runs the analyzer of l on file, parsed from src, as the only file in its
+// package and returns the diagnostics reported. Imports are not resolved and type
+// errors are ignored.
+func analyzeFile(l *Linter, fset *token.FileSet, file *ast.File, src []byte) ([]analysis.Diagnostic, error) {
+	pkg, info := checkFile(fset, file)
+	return runAnalyzer(l, fset, file, src, pkg, info, inspector.New([]*ast.File{file}))
+}
+
+// checkFile type checks file as the only file in its package, ignoring type errors.
+func checkFile(fset *token.FileSet, file *ast.File) (*types.Package, *types.Info) {
+	info := &types.Info{
+		Types:        make(map[ast.Expr]types.TypeAndValue),
+		Instances:    make(map[*ast.Ident]types.Instance),
+		Defs:         make(map[*ast.Ident]types.Object),
+		Uses:         make(map[*ast.Ident]types.Object),
+		Implicits:    make(map[ast.Node]types.Object),
+		Selections:   make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:       make(map[ast.Node]*types.Scope),
+		FileVersions: make(map[*ast.File]string),
+	}
+
+	conf := types.Config{Error: func(error) {}}
+	pkg, _ := conf.Check(file.Name.Name, fset, []*ast.File{file}, info)
+
+	return pkg, info
+}
+
+// runAnalyzer runs the analyzer of l on file, parsed from src and type checked by checkFile,
+// with the inspector of inspect.Analyzer for file, and returns the diagnostics reported.
+func runAnalyzer(l *Linter, fset *token.FileSet, file *ast.File, src []byte, pkg *types.Package, info *types.Info, insp *inspector.Inspector) ([]analysis.Diagnostic, error) {
+	var diags []analysis.Diagnostic
+	pass := &analysis.Pass{
+		Analyzer:  l.Analyzer,
+		Fset:      fset,
+		Files:     []*ast.File{file},
+		Pkg:       pkg,
+		TypesInfo: info,
+		Report: func(diag analysis.Diagnostic) {
+			diags = append(diags, diag)
+		},
+		ReadFile: func(filename string) ([]byte, error) {
+			if filename == fset.File(file.Pos()).Name() {
+				return src, nil
+			}
+			return nil, os.ErrNotExist
+		},
+		ResultOf: map[*analysis.Analyzer]interface{}{
+			inspect.Analyzer: insp,
+		},
+
+		// The package has no dependencies analyzed, so no facts to import.
+		ExportObjectFact: func(types.Object, analysis.Fact) {},
+		ImportObjectFact: func(types.Object, analysis.Fact) bool { return false },
+	}
+
+	_, err := l.Analyzer.Run(pass)
+	return diags, err
+}
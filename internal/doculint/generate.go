@@ -0,0 +1,34 @@
+package doculint
+
+import (
+	"go/ast"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// generateDirective is the prefix of the directives run by go generate.
+const generateDirective = "//go:generate "
+
+// checkGenerateDirectives reports the //go:generate directives of file that are not
+// preceded by a comment explaining what they generate and how to regenerate it, when
+// enabled. A comment may explain several consecutive directives.
+func (l *Linter) checkGenerateDirectives(pass *analysis.Pass, enabled bool, file *ast.File) {
+	if !enabled {
+		return
+	}
+
+	for _, cg := range file.Comments {
+		explained := false
+		for _, c := range cg.List {
+			if !strings.HasPrefix(c.Text, generateDirective) {
+				explained = explained || !isDirective(c.Text)
+				continue
+			}
+
+			if !explained {
+				l.report(pass, RuleGenerateComment, c.Pos(), "//go:generate directive should be preceded by a comment explaining what it generates and how to regenerate it")
+			}
+		}
+	}
+}
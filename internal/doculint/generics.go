@@ -0,0 +1,26 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkTypeParams reports the type parameters in params, of a generic function or type
+// described by what, that are not mentioned in its doc comment, when -type-params is
+// set, since the intent of their constraints is rarely obvious from the signature.
+func (l *Linter) checkTypeParams(pass *analysis.Pass, what string, pos token.Pos, params *ast.FieldList, doc *ast.CommentGroup) {
+	if !l.requireTypeParamDocs || params == nil || doc == nil {
+		return
+	}
+
+	text := doc.Text()
+	for _, field := range params.List {
+		for _, name := range field.Names {
+			if name.Name != "_" && !containsWord(text, name.Name) {
+				l.report(pass, RuleTypeParamComment, pos, "comment for %s should describe its type parameter \"%s\"", what, name.Name)
+			}
+		}
+	}
+}
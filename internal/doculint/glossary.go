@@ -0,0 +1,83 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkGlossary reports the deprecated synonyms of the glossary of the configuration
+// file found in the doc comment of a declaration described by what, outside of code
+// blocks, along with a fix replacing them with the preferred term. Synonyms are matched
+// case insensitively within a line of the comment, and the replacement is capitalized
+// when the synonym is.
+func (l *Linter) checkGlossary(pass *analysis.Pass, what string, doc *ast.CommentGroup) {
+	cfg, err := l.loadConfig()
+	if err != nil || len(cfg.Glossary) == 0 {
+		// The error is returned by the analyzer before any comment is checked.
+		return
+	}
+
+	// Sort the preferred terms so that the findings of a comment are reported in a
+	// stable order.
+	preferred := make([]string, 0, len(cfg.Glossary))
+	for term := range cfg.Glossary {
+		preferred = append(preferred, term)
+	}
+	sort.Strings(preferred)
+
+	for _, c := range doc.List {
+		if isDirective(c.Text) || strings.HasPrefix(c.Text, "//\t") || strings.HasPrefix(c.Text, "//  ") {
+			// Directives and code blocks are not prose.
+			continue
+		}
+
+		lower := strings.ToLower(c.Text)
+		if len(lower) != len(c.Text) {
+			// Offsets into the lower case text must be valid in the comment.
+			continue
+		}
+
+		for _, term := range preferred {
+			for _, synonym := range cfg.Glossary[term] {
+				synonym = strings.ToLower(strings.TrimSpace(synonym))
+				if synonym == "" {
+					continue
+				}
+
+				i := indexPhrase(lower, synonym)
+				if i < 0 {
+					continue
+				}
+
+				found := c.Text[i : i+len(synonym)]
+				replacement := term
+				if r, _ := utf8.DecodeRuneInString(found); unicode.IsUpper(r) {
+					first, size := utf8.DecodeRuneInString(term)
+					replacement = string(unicode.ToUpper(first)) + term[size:]
+				}
+
+				pos := c.Pos() + token.Pos(i)
+				l.reportDiagnostic(pass, RuleGlossary, analysis.Diagnostic{
+					Pos:     pos,
+					End:     pos + token.Pos(len(found)),
+					Message: fmt.Sprintf("comment for %s uses \"%s\", use the preferred term \"%s\" instead", what, found, term),
+					SuggestedFixes: []analysis.SuggestedFix{{
+						Message: fmt.Sprintf("Replace with \"%s\"", replacement),
+						TextEdits: []analysis.TextEdit{{
+							Pos:     pos,
+							End:     pos + token.Pos(len(found)),
+							NewText: []byte(replacement),
+						}},
+					}},
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// headerYear is the placeholder of the -header template standing for a year, or a
+// range of years such as 2019-2024.
+const headerYear = "{{YEAR}}"
+
+// loadHeader returns the text of the -header template and the pattern matching the
+// beginning of the files it is found at, reading the template the first time it is
+// called. Trailing whitespace is ignored on every line.
+func (l *Linter) loadHeader() (string, *regexp.Regexp, error) {
+	l.header.once.Do(func() {
+		data, err := os.ReadFile(l.headerPath)
+		if err != nil {
+			l.header.err = fmt.Errorf("read header: %w", err)
+			return
+		}
+
+		lines := strings.Split(strings.TrimRight(string(data), " \t\r\n"), "\n")
+		patterns := make([]string, len(lines))
+		for i := range lines {
+			lines[i] = strings.TrimRight(lines[i], " \t\r")
+
+			parts := strings.Split(lines[i], headerYear)
+			for j := range parts {
+				parts[j] = regexp.QuoteMeta(parts[j])
+			}
+			patterns[i] = strings.Join(parts, `\d{4}(?:\s*-\s*\d{4})?`) + `[ \t\r]*`
+		}
+
+		l.header.text = strings.Join(lines, "\n")
+		l.header.pattern, l.header.err = regexp.Compile(`^` + strings.Join(patterns, `\n`) + `(?:\n|$)`)
+	})
+
+	return l.header.text, l.header.pattern, l.header.err
+}
+
+// checkHeader reports file if it does not begin with the license or copyright header
+// of the -header template, when given. Generated files are ignored. Files beginning
+// with no header at all carry a fix inserting the template, with the current year in
+// place of its year placeholder, while files beginning with another header must be
+// corrected by hand.
+func (l *Linter) checkHeader(pass *analysis.Pass, file *ast.File) {
+	if l.headerPath == "" || ast.IsGenerated(file) {
+		return
+	}
+
+	text, pattern, err := l.loadHeader()
+	if err != nil {
+		// The error is returned by the analyzer before any file is checked.
+		return
+	}
+
+	src, err := pass.ReadFile(pass.Fset.File(file.Package).Name())
+	if err != nil || pattern.Match(src) {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     file.FileStart,
+		Message: fmt.Sprintf("file should begin with the header in \"%s\"", l.headerPath),
+	}
+
+	if len(file.Comments) == 0 || file.Comments[0].Pos() > file.Package || !isLicenseHeader(file.Comments[0]) {
+		year := strconv.Itoa(time.Now().Year())
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Insert the header",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     file.FileStart,
+				End:     file.FileStart,
+				NewText: []byte(strings.ReplaceAll(text, headerYear, year) + "\n\n"),
+			}},
+		}}
+	}
+
+	l.reportDiagnostic(pass, RuleFileHeader, diag)
+}
@@ -0,0 +1,144 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// minLanguageWords is the minimum number of words a comment must have for its language
+// to be detected, since short comments are mostly identifiers.
+const minLanguageWords = 5
+
+// minLanguageStopwords is the minimum number of stopwords of another language a comment
+// must have to be reported as written in that language.
+const minLanguageStopwords = 2
+
+// language is a language doc comments can be required to be written in.
+type language struct {
+	// name is the English name of the language.
+	name string
+
+	// latin reports whether the language is written in the Latin script.
+	latin bool
+
+	// stopwords are the most frequent words of the language, in lower case, used to
+	// detect it.
+	stopwords []string
+}
+
+// languages maps ISO 639-1 codes to the languages that can be given to -language.
+var languages = map[string]language{
+	"en": {name: "English", latin: true, stopwords: []string{"the", "and", "is", "are", "of", "to", "in", "that", "for", "with", "this", "be", "it", "if", "or", "an", "by", "as", "on", "not", "when", "which", "from"}},
+	"de": {name: "German", latin: true, stopwords: []string{"der", "die", "das", "und", "ist", "sind", "nicht", "ein", "eine", "mit", "für", "von", "zu", "den", "dem", "wird", "auf", "sich", "oder", "wenn", "ob"}},
+	"fr": {name: "French", latin: true, stopwords: []string{"le", "la", "les", "et", "est", "de", "des", "une", "un", "du", "pour", "dans", "que", "qui", "avec", "pas", "sur", "ce", "sont", "ou", "si"}},
+	"es": {name: "Spanish", latin: true, stopwords: []string{"el", "la", "los", "las", "y", "es", "de", "que", "en", "un", "una", "para", "con", "por", "del", "se", "no", "si", "o"}},
+	"it": {name: "Italian", latin: true, stopwords: []string{"il", "lo", "la", "gli", "le", "e", "è", "di", "che", "un", "una", "per", "con", "non", "del", "della", "sono", "se", "o"}},
+	"pt": {name: "Portuguese", latin: true, stopwords: []string{"o", "a", "os", "as", "e", "é", "de", "que", "um", "uma", "para", "com", "não", "do", "da", "em", "se", "ou"}},
+	"nl": {name: "Dutch", latin: true, stopwords: []string{"de", "het", "een", "en", "is", "van", "niet", "dat", "die", "voor", "met", "op", "te", "zijn", "wordt", "als", "of"}},
+}
+
+// languageCode is the ISO 639-1 code of a language in languages, or empty to disable
+// the check, used as a flag.Value.
+type languageCode string
+
+// String returns the code of the language.
+func (l *languageCode) String() string {
+	return string(*l)
+}
+
+// Set sets the language from its code.
+func (l *languageCode) Set(s string) error {
+	if _, ok := languages[s]; !ok && s != "" {
+		codes := make([]string, 0, len(languages))
+		for code := range languages {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+
+		return fmt.Errorf("unknown language \"%s\", expected one of %s", s, strings.Join(codes, ", "))
+	}
+
+	*l = languageCode(s)
+	return nil
+}
+
+// checkLanguage reports the doc comment of an exported declaration described by what if
+// it appears to be written in a language other than -language. The language of a
+// comment is detected from its stopwords, the most frequent words of each language,
+// ignoring code blocks, code spans, doc links, and URLs. A comment is only reported
+// when it has more stopwords of another language than of the expected one, or when most
+// of its letters are not from the script of the expected language.
+func (l *Linter) checkLanguage(pass *analysis.Pass, what string, pos token.Pos, doc *ast.CommentGroup) {
+	expected, ok := languages[string(l.docLanguage)]
+	if !ok {
+		return
+	}
+
+	var prose []string
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		if indentation(line) == 0 {
+			prose = append(prose, line)
+		}
+	}
+
+	text := strings.Join(prose, " ")
+	for _, pattern := range spellMaskPatterns {
+		text = pattern.ReplaceAllString(text, " ")
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) < minLanguageWords {
+		return
+	}
+
+	if expected.latin && !mostlyLatin(text) {
+		l.report(pass, RuleDocLanguage, pos, "comment for %s appears not to be written in %s", what, expected.name)
+		return
+	}
+
+	counts := make(map[string]int)
+	for code, lang := range languages {
+		for _, word := range words[1:] {
+			if contains(lang.stopwords, word) {
+				counts[code]++
+			}
+		}
+	}
+
+	best := string(l.docLanguage)
+	for code, n := range counts {
+		if n > counts[best] || n == counts[best] && code < best && best != string(l.docLanguage) {
+			best = code
+		}
+	}
+
+	if best != string(l.docLanguage) && counts[best] >= minLanguageStopwords {
+		l.report(pass, RuleDocLanguage, pos, "comment for %s appears to be written in %s rather than %s", what, languages[best].name, expected.name)
+	}
+}
+
+// mostlyLatin reports whether at least half of the letters of text are from the Latin
+// script.
+func mostlyLatin(text string) bool {
+	var letters, latin int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		letters++
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+		}
+	}
+
+	return latin*2 >= letters
+}
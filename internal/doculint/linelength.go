@@ -0,0 +1,146 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// commentLine is a single line of a doc comment written as a line comment.
+type commentLine struct {
+	// comment is the comment making up the line.
+	comment *ast.Comment
+
+	// text is the text of the comment without the comment marker and the single space
+	// that conventionally follows it.
+	text string
+}
+
+// isProse reports whether the line is part of a prose paragraph, as opposed to a blank
+// line, a directive, or a line indented as part of a code block or list.
+func (l commentLine) isProse() bool {
+	return strings.TrimSpace(l.text) != "" && !isDirective(l.comment.Text) && indentation(l.text) == 0
+}
+
+// checkLineLength reports lines of doc comment that are longer than the -line-length
+// limit, counted in characters including the indentation of the comment. Lines that
+// are part of code blocks or lists, directives, and lines containing URLs are exempt,
+// as are block comments. With -rewrap, the first long line of every paragraph carries
+// a fix that rewraps the paragraph to fit within the limit.
+func (l *Linter) checkLineLength(pass *analysis.Pass, doc *ast.CommentGroup) {
+	if l.maxLineLength <= 0 {
+		return
+	}
+
+	var lines []commentLine
+	for _, c := range doc.List {
+		if !strings.HasPrefix(c.Text, "//") {
+			return
+		}
+
+		text := strings.TrimPrefix(c.Text, "//")
+		lines = append(lines, commentLine{comment: c, text: strings.TrimPrefix(text, " ")})
+	}
+
+	for start := 0; start < len(lines); {
+		if !lines[start].isProse() {
+			start++
+			continue
+		}
+
+		end := start + 1
+		for end < len(lines) && lines[end].isProse() {
+			end++
+		}
+
+		l.checkParagraphLength(pass, lines[start:end])
+		start = end
+	}
+}
+
+// checkParagraphLength reports the lines of the given prose paragraph of a doc comment
+// that are longer than the -line-length limit.
+func (l *Linter) checkParagraphLength(pass *analysis.Pass, paragraph []commentLine) {
+	offered := false
+
+	for _, line := range paragraph {
+		position := pass.Fset.Position(line.comment.Pos())
+		length := position.Column - 1 + utf8.RuneCountInString(line.comment.Text)
+		if length <= l.maxLineLength || strings.Contains(line.text, "://") {
+			continue
+		}
+
+		diag := analysis.Diagnostic{
+			Pos:     line.comment.Pos(),
+			End:     line.comment.End(),
+			Message: fmt.Sprintf("comment line is %d characters long, which exceeds the limit of %d", length, l.maxLineLength),
+		}
+
+		if l.rewrapComments && !offered {
+			if fix, ok := l.rewrapFix(pass, paragraph, position.Column-1); ok {
+				diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+				offered = true
+			}
+		}
+
+		l.reportDiagnostic(pass, RuleLineLength, diag)
+	}
+}
+
+// rewrapFix returns a fix that rewraps the words of paragraph so that its lines fit
+// within the -line-length limit, given the indentation of the paragraph in columns.
+// Words longer than the limit are placed on lines of their own.
+func (l *Linter) rewrapFix(pass *analysis.Pass, paragraph []commentLine, indent int) (analysis.SuggestedFix, bool) {
+	first, last := paragraph[0].comment, paragraph[len(paragraph)-1].comment
+
+	src, err := pass.ReadFile(pass.Fset.File(first.Pos()).Name())
+	if err != nil {
+		return analysis.SuggestedFix{}, false
+	}
+
+	tf := pass.Fset.File(first.Pos())
+	offset := tf.Offset(first.Pos())
+	lineStart := offset - indent
+	if lineStart < 0 {
+		return analysis.SuggestedFix{}, false
+	}
+	prefix := string(src[lineStart:offset])
+
+	var words []string
+	for _, line := range paragraph {
+		words = append(words, strings.Fields(line.text)...)
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, word := range words {
+		wordWidth := utf8.RuneCountInString(word)
+
+		switch {
+		case width == 0:
+			b.WriteString("// ")
+			width = indent + len("// ")
+		case width+1+wordWidth > l.maxLineLength:
+			b.WriteString("\n" + prefix + "// ")
+			width = indent + len("// ")
+		default:
+			b.WriteString(" ")
+			width++
+		}
+
+		b.WriteString(word)
+		width += wordWidth
+	}
+
+	return analysis.SuggestedFix{
+		Message: "Rewrap the paragraph",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     first.Pos(),
+			End:     last.End(),
+			NewText: []byte(b.String()),
+		}},
+	}, true
+}
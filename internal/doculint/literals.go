@@ -0,0 +1,200 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"math"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// checkConditionLiterals reports the literals found on either side of the binary
+// expression cond, the condition of an if statement or of a case of a switch statement
+// without a tag, unless they are allowed by allowedLiteral.
+func (l *Linter) checkConditionLiterals(pass *analysis.Pass, cond ast.Expr) {
+	be, ok := cond.(*ast.BinaryExpr)
+	if !ok {
+		return
+	}
+
+	if literal, text := basicLiteral(be.X); literal != nil && !l.allowedLiteral(literal, text) {
+		l.report(pass, RuleConditionalLiteral, be.X.Pos(), "literal found in conditional")
+	}
+
+	if literal, text := basicLiteral(be.Y); literal != nil && !l.allowedLiteral(literal, text) {
+		l.report(pass, RuleConditionalLiteral, be.Y.Pos(), "literal found in conditional")
+	}
+}
+
+// checkSwitchLiterals reports the literals found in the tag of stmt and the numeric
+// literals found in the expressions of its cases, which are compared to the tag, unless
+// they are allowed by allowedLiteral. String and rune cases, as in case "serve":, are
+// the idiomatic way of matching a tag against a set of names and are not reported. The
+// cases of a switch statement without a tag are conditions, checked as those of if
+// statements.
+func (l *Linter) checkSwitchLiterals(pass *analysis.Pass, stmt *ast.SwitchStmt) {
+	if literal, text := basicLiteral(stmt.Tag); literal != nil && !l.allowedLiteral(literal, text) {
+		l.report(pass, RuleConditionalLiteral, stmt.Tag.Pos(), "literal found in switch tag")
+	}
+
+	for _, clause := range stmt.Body.List {
+		cc, ok := clause.(*ast.CaseClause)
+		if !ok {
+			continue
+		}
+
+		for _, expr := range cc.List {
+			if stmt.Tag == nil {
+				l.checkConditionLiterals(pass, expr)
+				continue
+			}
+
+			if literal := l.numericLiteral(expr); literal != "" {
+				l.report(pass, RuleConditionalLiteral, expr.Pos(), "literal found in switch case")
+			}
+		}
+	}
+}
+
+// checkCallLiterals reports the numeric literals passed as arguments to call, when
+// -call-literals is set, unless the called function is listed in -call-literal-exempt
+// or the literals are allowed by allowedLiteral.
+// Type conversions, such as time.Duration(5), are not calls and are ignored.
+func (l *Linter) checkCallLiterals(pass *analysis.Pass, call *ast.CallExpr) {
+	if !l.reportCallLiterals {
+		return
+	}
+
+	if tv, ok := pass.TypesInfo.Types[call.Fun]; ok && tv.IsType() {
+		return
+	}
+
+	name := calleeName(pass.TypesInfo, call)
+	if name == "" || contains(l.callLiteralExempt, name) {
+		return
+	}
+
+	for _, arg := range call.Args {
+		if literal := l.numericLiteral(arg); literal != "" {
+			l.report(pass, RuleMagicLiteral, arg.Pos(), "numeric literal %s passed to %s, use a named constant", literal, name)
+		}
+	}
+}
+
+// numericLiteral returns the source text of expr if it is a numeric literal, possibly
+// negated, that is not allowed by allowedLiteral, or an empty string otherwise.
+func (l *Linter) numericLiteral(expr ast.Expr) string {
+	literal, text := basicLiteral(expr)
+	if literal == nil || !isNumeric(literal.Kind) || l.allowedLiteral(literal, text) {
+		return ""
+	}
+
+	return text
+}
+
+// basicLiteral returns expr if it is a literal, possibly negated, along with its source
+// text including the sign, or nil otherwise.
+func basicLiteral(expr ast.Expr) (*ast.BasicLit, string) {
+	sign := ""
+	if unary, ok := expr.(*ast.UnaryExpr); ok && (unary.Op == token.SUB || unary.Op == token.ADD) {
+		sign, expr = unary.Op.String(), unary.X
+	}
+
+	literal, ok := expr.(*ast.BasicLit)
+	if !ok {
+		return nil, ""
+	}
+
+	return literal, sign + literal.Value
+}
+
+// isNumeric reports whether kind is the kind of a numeric literal, as opposed to a
+// string or rune literal.
+func isNumeric(kind token.Token) bool {
+	return kind == token.INT || kind == token.FLOAT || kind == token.IMAG
+}
+
+// allowedLiteral reports whether literal, whose source text including its sign is text,
+// may be used without being named. Numeric literals are allowed when -numeric-literals
+// is disabled or when their absolute value is below -literal-threshold, string and rune
+// literals when -string-literals is disabled, and any literal listed in
+// -allowed-literals.
+func (l *Linter) allowedLiteral(literal *ast.BasicLit, text string) bool {
+	if contains(l.allowedLiterals, text) {
+		return true
+	}
+
+	if !isNumeric(literal.Kind) {
+		return !l.reportStringLiterals
+	}
+
+	if !l.reportNumericLiterals {
+		return true
+	}
+
+	if l.literalThreshold > 0 && literal.Kind != token.IMAG {
+		value, _ := constant.Float64Val(constant.MakeFromLiteral(literal.Value, literal.Kind, 0))
+		return math.Abs(value) < l.literalThreshold
+	}
+
+	return false
+}
+
+// calleeName returns the name of the function called by call, qualified by its package
+// name, such as "time.Sleep", by its receiver type for methods, such as
+// "Builder.Grow", or unqualified for builtins, such as "make". It returns an empty
+// string for calls of function values.
+func calleeName(info *types.Info, call *ast.CallExpr) string {
+	switch callee := typeutil.Callee(info, call).(type) {
+	case *types.Builtin:
+		return callee.Name()
+	case *types.Func:
+		if recv := callee.Signature().Recv(); recv != nil {
+			t := recv.Type()
+			if ptr, ok := t.(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+
+			if named, ok := t.(*types.Named); ok {
+				return named.Obj().Name() + "." + callee.Name()
+			}
+
+			return callee.Name()
+		}
+
+		if callee.Pkg() == nil {
+			return callee.Name()
+		}
+
+		return callee.Pkg().Name() + "." + callee.Name()
+	}
+
+	return ""
+}
+
+// checkReturnLiterals reports the numeric literals returned by the exported function fn,
+// when -return-literals is set, unless they are allowed by allowedLiteral. Return
+// statements within function literals are ignored, as they do not return from fn.
+func (l *Linter) checkReturnLiterals(pass *analysis.Pass, fn *ast.FuncDecl) {
+	if !l.reportReturnLiterals || fn.Body == nil || !fn.Name.IsExported() {
+		return
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				if literal := l.numericLiteral(result); literal != "" {
+					l.report(pass, RuleMagicLiteral, result.Pos(), "numeric literal %s returned by %s, use a named constant", literal, fn.Name.Name)
+				}
+			}
+		}
+
+		return true
+	})
+}
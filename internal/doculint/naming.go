@@ -0,0 +1,203 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkPackageName validates the name of the package analyzed by pass against the Go
+// conventions and, as far as they are enabled, the quality checks on its length,
+// meaning, and plurality. The findings are reported at pos, the package clause chosen by
+// packagePos. The _test suffix of external test packages is ignored.
+func (l *Linter) checkPackageName(pass *analysis.Pass, pos token.Pos) {
+	pkg := pass.Pkg.Name()
+	if onlyTestFiles(pass) {
+		pkg = strings.TrimSuffix(pkg, "_test")
+	}
+
+	if msg := validatePackageName(pkg); msg != "" {
+		l.report(pass, RulePackageName, pos, "%s", msg)
+	}
+
+	if pkg == "main" {
+		return
+	}
+
+	if l.maxPackageNameLength > 0 && utf8.RuneCountInString(pkg) > l.maxPackageNameLength {
+		l.report(pass, RulePackageNameQuality, pos, "package \"%s\" is longer than %d characters, package names should be short", pkg, l.maxPackageNameLength)
+	}
+
+	if contains(l.genericPackageNames, pkg) {
+		l.report(pass, RulePackageNameQuality, pos, "package \"%s\" has a meaningless name, name it after what it provides", pkg)
+	}
+
+	if path, ok := stdlibPackages[pkg]; ok && l.checkStdlibShadows && pass.Pkg.Path() != path && !contains(l.allowedStdlibNames, pkg) {
+		l.report(pass, RuleStdlibShadow, pos, "package \"%s\" shadows the standard library package \"%s\", forcing an import alias on code using both", pkg, path)
+	}
+
+	if singular := singularName(pkg); l.checkPluralPackageNames && singular != "" {
+		l.report(pass, RulePackageNamePlural, pos, "package \"%s\" should have a singular name, such as \"%s\"", pkg, singular)
+	}
+}
+
+// stdlibPackages maps the names of popular standard library packages to their import
+// paths.
+var stdlibPackages = map[string]string{
+	"ast":      "go/ast",
+	"atomic":   "sync/atomic",
+	"base64":   "encoding/base64",
+	"big":      "math/big",
+	"binary":   "encoding/binary",
+	"bufio":    "bufio",
+	"bytes":    "bytes",
+	"context":  "context",
+	"csv":      "encoding/csv",
+	"embed":    "embed",
+	"errors":   "errors",
+	"exec":     "os/exec",
+	"filepath": "path/filepath",
+	"flag":     "flag",
+	"fmt":      "fmt",
+	"hex":      "encoding/hex",
+	"http":     "net/http",
+	"io":       "io",
+	"json":     "encoding/json",
+	"log":      "log",
+	"maps":     "maps",
+	"math":     "math",
+	"net":      "net",
+	"os":       "os",
+	"path":     "path",
+	"rand":     "math/rand",
+	"reflect":  "reflect",
+	"regexp":   "regexp",
+	"runtime":  "runtime",
+	"slices":   "slices",
+	"slog":     "log/slog",
+	"sort":     "sort",
+	"sql":      "database/sql",
+	"strconv":  "strconv",
+	"strings":  "strings",
+	"sync":     "sync",
+	"template": "text/template",
+	"testing":  "testing",
+	"time":     "time",
+	"tls":      "crypto/tls",
+	"token":    "go/token",
+	"types":    "go/types",
+	"unicode":  "unicode",
+	"url":      "net/url",
+	"xml":      "encoding/xml",
+}
+
+// pluralExceptions are the package names that look plural but are not, or are plural
+// by convention to avoid clashing with the predeclared identifiers and types, such as
+// "errors" and "strings" in the standard library.
+var pluralExceptions = map[string]bool{
+	"bytes":       true,
+	"constraints": true,
+	"errors":      true,
+	"maps":        true,
+	"news":        true,
+	"slices":      true,
+	"stats":       true,
+	"strings":     true,
+	"types":       true,
+}
+
+// singularName returns the singular form of the package name pkg if it looks plural,
+// such as "model" for "models", or an empty string otherwise.
+func singularName(pkg string) string {
+	if len(pkg) <= 3 || pluralExceptions[pkg] || !strings.HasSuffix(pkg, "s") {
+		return ""
+	}
+
+	for _, suffix := range []string{"ss", "us", "is", "as", "os"} {
+		if strings.HasSuffix(pkg, suffix) {
+			return ""
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(pkg, "ies"):
+		return strings.TrimSuffix(pkg, "ies") + "y"
+	case strings.HasSuffix(pkg, "sses"), strings.HasSuffix(pkg, "xes"), strings.HasSuffix(pkg, "ches"), strings.HasSuffix(pkg, "shes"):
+		return strings.TrimSuffix(pkg, "es")
+	}
+
+	return strings.TrimSuffix(pkg, "s")
+}
+
+// validatePackageName ensures that a given package name follows the conventions that can
+// be read about here: https://blog.golang.org/package-names
+func validatePackageName(pkg string) string {
+	if strings.ContainsAny(pkg, "_-") {
+		return fmt.Sprintf("package \"%s\" should not contain - or _ in name", pkg)
+	}
+
+	if pkg != strings.ToLower(pkg) {
+		return fmt.Sprintf("package \"%s\" should be all lowercase", pkg)
+	}
+
+	return ""
+}
+
+// checkStutter reports the exported package-level identifiers declared in file that
+// repeat the package name, such as "doculint.DoculintAnalyzer", when -stutter is set,
+// since callers already qualify them with the package name. Methods, test files, and
+// main packages are ignored.
+func (l *Linter) checkStutter(pass *analysis.Pass, file *ast.File) {
+	pkg := pass.Pkg.Name()
+	if !l.checkStutters || pkg == "main" || strings.HasSuffix(pass.Fset.Position(file.Package).Filename, "_test.go") {
+		return
+	}
+
+	check := func(what string, ident *ast.Ident) {
+		if ident.IsExported() && stutters(pkg, ident.Name) {
+			l.report(pass, RuleStutter, ident.Pos(), "%s \"%s\" repeats the package name, callers will write it as \"%s.%s\"", what, ident.Name, pkg, ident.Name)
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				check("function", decl.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					check("type", spec.Name)
+				case *ast.ValueSpec:
+					what := "variable"
+					if decl.Tok == token.CONST {
+						what = "constant"
+					}
+
+					for _, name := range spec.Names {
+						check(what, name)
+					}
+				}
+			}
+		}
+	}
+}
+
+// stutters reports whether name begins with the package name pkg, regardless of case,
+// followed by the start of another word, such as "HTTPServer" in package "http". A name
+// equal to the package name, such as "context.Context", does not stutter.
+func stutters(pkg, name string) bool {
+	if len(name) <= len(pkg) || !strings.EqualFold(name[:len(pkg)], pkg) {
+		return false
+	}
+
+	r, _ := utf8.DecodeRuneInString(name[len(pkg):])
+	return unicode.IsUpper(r) || unicode.IsDigit(r) || r == '_'
+}
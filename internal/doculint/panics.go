@@ -0,0 +1,42 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkPanics reports the exported function fn if its body calls panic but its comment
+// does not mention that it panics, when -panic-docs is set. Calls within function
+// literals are ignored, as they may never be run by fn.
+func (l *Linter) checkPanics(pass *analysis.Pass, fn *ast.FuncDecl) {
+	if !l.requirePanicDocs || fn.Body == nil || !fn.Name.IsExported() {
+		return
+	}
+
+	if fn.Doc != nil && strings.Contains(strings.ToLower(fn.Doc.Text()), "panic") {
+		return
+	}
+
+	panicFunc := types.Universe.Lookup("panic")
+
+	var call *ast.CallExpr
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if id, ok := ast.Unparen(n.Fun).(*ast.Ident); ok && pass.TypesInfo.Uses[id] == panicFunc && call == nil {
+				call = n
+			}
+		}
+
+		return call == nil
+	})
+
+	if call != nil {
+		l.report(pass, RulePanicComment, fn.Pos(), "comment for function \"%s\" should document that it panics, as it calls panic at line %d", fn.Name.Name, pass.Fset.Position(call.Pos()).Line)
+	}
+}
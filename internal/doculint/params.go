@@ -0,0 +1,54 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// paramReferencePatterns match the identifiers written as code, such as `name`, or as
+// doc links, such as [name], in comment text, capturing the identifier. Doc links are
+// only matched for identifiers beginning with a lower case letter or underscore, as
+// exported identifiers are validated as doc links.
+var paramReferencePatterns = []*regexp.Regexp{
+	regexp.MustCompile("`([A-Za-z_][A-Za-z0-9_]*)`"),
+	regexp.MustCompile(`\[([a-z_][A-Za-z0-9_]*)\]($|[^:(])`),
+}
+
+// checkParamReferences reports the identifiers referenced in the comment of the function
+// fn, in code spans or doc links, that are not the names of its parameters, results,
+// receiver, or type parameters, nor declared in the package, its imports, or the
+// universe, if -params is set. Such references are usually left over from a change to
+// the signature of the function.
+func (l *Linter) checkParamReferences(pass *analysis.Pass, fn *ast.FuncDecl) {
+	if !l.checkParams {
+		return
+	}
+
+	names := funcLocalNames(fn)
+
+	imports := fileImports(pass, fn.Pos())
+	reported := make(map[string]bool)
+
+	for _, line := range strings.Split(fn.Doc.Text(), "\n") {
+		if indentation(line) > 0 {
+			// Code blocks may refer to anything.
+			continue
+		}
+
+		for _, pattern := range paramReferencePatterns {
+			for _, m := range pattern.FindAllStringSubmatch(line, -1) {
+				name := m[1]
+				if names[name] || reported[name] || imports[name] != "" || pass.Pkg.Scope().Lookup(name) != nil || types.Universe.Lookup(name) != nil {
+					continue
+				}
+				reported[name] = true
+
+				l.report(pass, RuleParamReference, fn.Pos(), "comment for function \"%s\" refers to \"%s\", which is not a parameter, result, or receiver of the function", fn.Name.Name, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// prefixFix returns a fix beginning doc, the first one of its comments other than
+// directives holding text, with expected. When the first word of the comment is a
+// variant of the last word of expected, differing in case or by a typo, as in "newFoo"
+// for "NewFoo", it is replaced, along with the words before it when expected has
+// several words and the comment begins with all but the last of them in another case,
+// as in "package Foo" for "Package foo". Otherwise expected is inserted before the text
+// of the comment, whose first word is lowercased if it is a capitalized word, as in
+// "Returns" or "Items", so that "Returns the foo." becomes "Foo returns the foo.". Words
+// are made of letters, digits, underscores, and dots, so that punctuation following
+// them, such as the colon of "foo: ...", is kept. No fix is returned if expected is
+// empty, if the comment does not begin with a word, or if its first words already are
+// expected.
+func prefixFix(doc *ast.CommentGroup, expected string) (analysis.SuggestedFix, bool) {
+	want := strings.Fields(expected)
+	if len(want) == 0 {
+		return analysis.SuggestedFix{}, false
+	}
+
+	for _, c := range doc.List {
+		if isDirective(c.Text) {
+			continue
+		}
+
+		words := leadingWords(c, len(want))
+		if len(words) == 0 {
+			// The comment holds no text, or text that does not begin with a word.
+			if strings.TrimSpace(commentBody(c.Text)) == "" {
+				continue
+			}
+			return analysis.SuggestedFix{}, false
+		}
+
+		text := func(w span) string {
+			return c.Text[w.pos-c.Slash : w.end-c.Slash]
+		}
+
+		var replaced span
+		if len(words) == len(want) && isVariant(text(words[len(words)-1]), want[len(want)-1]) {
+			replaced = span{words[0].pos, words[len(words)-1].end}
+			for i, w := range words[:len(words)-1] {
+				if !strings.EqualFold(text(w), want[i]) {
+					replaced = span{}
+					break
+				}
+			}
+		}
+		if !replaced.pos.IsValid() && isVariant(text(words[0]), want[len(want)-1]) {
+			replaced = words[0]
+		}
+
+		if replaced.pos.IsValid() {
+			if text(replaced) == expected {
+				// The comment begins with expected after text that is not a word, such
+				// as the asterisks of a block comment, which godoc shows.
+				return analysis.SuggestedFix{}, false
+			}
+
+			return analysis.SuggestedFix{
+				Message: "Begin the comment with \"" + expected + "\"",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     replaced.pos,
+					End:     replaced.end,
+					NewText: []byte(expected),
+				}},
+			}, true
+		}
+
+		edit := analysis.TextEdit{
+			Pos:     words[0].pos,
+			End:     words[0].pos,
+			NewText: []byte(expected + " "),
+		}
+		if first := text(words[0]); isCapitalized(first) {
+			r, size := utf8.DecodeRuneInString(first)
+			edit.End += token.Pos(size)
+			edit.NewText = append(edit.NewText, string(unicode.ToLower(r))...)
+		}
+
+		return analysis.SuggestedFix{
+			Message:   "Begin the comment with \"" + expected + "\"",
+			TextEdits: []analysis.TextEdit{edit},
+		}, true
+	}
+
+	return analysis.SuggestedFix{}, false
+}
+
+// isVariant reports whether word is the identifier name written in another case, or
+// with typos: up to one edit for every four letters of name, an edit being the
+// insertion, deletion, or substitution of a letter, or the swap of two adjacent ones.
+func isVariant(word, name string) bool {
+	a, b := []rune(strings.ToLower(word)), []rune(strings.ToLower(name))
+
+	// d[i][j] is the number of edits between the first i runes of a and the first j
+	// runes of b.
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(a)][len(b)] <= len(b)/4
+}
+
+// isCapitalized reports whether word is an uppercase letter followed by lowercase ones,
+// as the first word of a sentence is, rather than an acronym or an identifier.
+func isCapitalized(word string) bool {
+	for i, r := range word {
+		if (i == 0) != unicode.IsUpper(r) || (i > 0 && !unicode.IsLower(r)) {
+			return false
+		}
+	}
+
+	return word != ""
+}
+
+// leadingWords returns the ranges of up to n words beginning the body of c, separated
+// by blanks, the stars beginning the lines of block comments included. Dots ending a
+// word, such as the period of a sentence, are not part of it. Nothing is returned if
+// the body does not begin with a word.
+func leadingWords(c *ast.Comment, n int) []span {
+	end := len(c.Text)
+	if strings.HasPrefix(c.Text, "/*") {
+		end -= len("*/")
+	}
+
+	var words []span
+	i := len("//")
+	for i < end && len(words) < n {
+		r, size := utf8.DecodeRuneInString(c.Text[i:end])
+		if unicode.IsSpace(r) || (r == '*' && c.Text[1] == '*') {
+			i += size
+			continue
+		}
+
+		start := i
+		for i < end {
+			r, size := utf8.DecodeRuneInString(c.Text[i:end])
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' {
+				break
+			}
+			i += size
+		}
+
+		word := strings.TrimRight(c.Text[start:i], ".")
+		if word == "" {
+			break
+		}
+		words = append(words, span{c.Slash + token.Pos(start), c.Slash + token.Pos(start+len(word))})
+
+		if len(word) < i-start {
+			// The word ends a sentence.
+			break
+		}
+	}
+
+	return words
+}
+
+// commentBody returns the text of the comment text without its markers.
+func commentBody(text string) string {
+	if strings.HasPrefix(text, "/*") {
+		return strings.TrimSuffix(text[len("/*"):], "*/")
+	}
+
+	return strings.TrimPrefix(text, "//")
+}
@@ -0,0 +1,192 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// readmeReference is a reference to an identifier of the analyzed package found in a
+// README.
+type readmeReference struct {
+	// offset is the byte offset of the reference within the README.
+	offset int
+
+	// text is the reference as written, such as "pkg.Type.Method".
+	text string
+
+	// name is the name of the referenced identifier, or of its receiver type if method
+	// is set.
+	name string
+
+	// method is the name of the referenced method or field, if any.
+	method string
+}
+
+// fencePattern matches the fenced code blocks of a markdown document.
+var fencePattern = regexp.MustCompile("(?ms)^[ \t]*(```|~~~).*?^[ \t]*(```|~~~)[ \t]*$")
+
+// spanPattern matches the inline code spans of a markdown document.
+var spanPattern = regexp.MustCompile("`([^`\n]+)`")
+
+// checkReadme verifies that the identifiers of the analyzed package referenced in the
+// README named by the -readme flag, found in the directory of the package, exist and
+// are documented. References are identifiers qualified with the package name, such as
+// pkg.Name or pkg.Type.Method, within fenced code blocks or inline code spans.
+func (l *Linter) checkReadme(pass *analysis.Pass) {
+	if l.readmePath == "" || len(pass.Files) == 0 || filepath.IsAbs(l.readmePath) {
+		return
+	}
+
+	dir := filepath.Dir(pass.Fset.Position(pass.Files[0].Package).Filename)
+	path := filepath.Join(dir, l.readmePath)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	tf := pass.Fset.AddFile(path, -1, len(content))
+	tf.SetLinesForContent(content)
+
+	documented := documentedIdentifiers(pass.Files)
+	base := filepath.Base(l.readmePath)
+
+	for _, ref := range readmeReferences(pass.Pkg.Name(), string(content)) {
+		pos := tf.Pos(ref.offset)
+
+		obj := pass.Pkg.Scope().Lookup(ref.name)
+		if obj == nil {
+			l.report(pass, RuleReadme, pos, "%s refers to \"%s\" which is not declared in package \"%s\"", base, ref.text, pass.Pkg.Name())
+			continue
+		}
+
+		key := ref.name
+		if ref.method != "" {
+			tn, ok := obj.(*types.TypeName)
+			if !ok {
+				l.report(pass, RuleReadme, pos, "%s refers to \"%s\" but \"%s\" is not a type", base, ref.text, ref.name)
+				continue
+			}
+
+			if m, _, _ := types.LookupFieldOrMethod(tn.Type(), true, pass.Pkg, ref.method); m == nil {
+				l.report(pass, RuleReadme, pos, "%s refers to \"%s\" which is not a field or method of \"%s\"", base, ref.text, ref.name)
+				continue
+			}
+
+			key = ref.name + "." + ref.method
+		}
+
+		if present, ok := documented[key]; ok && !present {
+			l.report(pass, RuleReadme, pos, "%s refers to \"%s\" which has no comment associated with it", base, ref.text)
+		}
+	}
+}
+
+// readmeReferences returns the references to identifiers of the package named pkg in
+// the fenced code blocks and inline code spans of the markdown document content.
+func readmeReferences(pkg, content string) []readmeReference {
+	ident := regexp.QuoteMeta(pkg) + `\.([A-Z][A-Za-z0-9_]*)(?:\.([A-Z][A-Za-z0-9_]*))?`
+	inBlock := regexp.MustCompile(`\b` + ident)
+	inSpan := regexp.MustCompile(`^` + ident + `(?:\(.*\))?$`)
+
+	var refs []readmeReference
+	add := func(offset int, text string, m []string) {
+		refs = append(refs, readmeReference{offset: offset, text: text, name: m[1], method: m[2]})
+	}
+
+	fences := fencePattern.FindAllStringIndex(content, -1)
+	for _, fence := range fences {
+		block := content[fence[0]:fence[1]]
+		for _, loc := range inBlock.FindAllStringSubmatchIndex(block, -1) {
+			m := submatches(block, loc)
+			add(fence[0]+loc[0], m[0], m)
+		}
+	}
+
+	for _, loc := range spanPattern.FindAllStringSubmatchIndex(content, -1) {
+		if withinAny(loc[0], fences) {
+			continue
+		}
+
+		span := content[loc[2]:loc[3]]
+		if m := inSpan.FindStringSubmatch(strings.TrimSpace(span)); m != nil {
+			add(loc[2], span, m)
+		}
+	}
+
+	return refs
+}
+
+// submatches returns the submatches of s located by loc, as returned by
+// regexp.Regexp.FindAllStringSubmatchIndex, with unmatched groups left empty.
+func submatches(s string, loc []int) []string {
+	m := make([]string, len(loc)/2)
+	for i := range m {
+		if loc[2*i] >= 0 {
+			m[i] = s[loc[2*i]:loc[2*i+1]]
+		}
+	}
+
+	return m
+}
+
+// withinAny reports whether offset is within any of the given [start, end) ranges.
+func withinAny(offset int, ranges [][]int) bool {
+	for _, r := range ranges {
+		if offset >= r[0] && offset < r[1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// documentedIdentifiers returns whether each package level function, type, constant,
+// and variable in files, and each method keyed by "Type.Method", has a comment.
+func documentedIdentifiers(files []*ast.File) map[string]bool {
+	documented := make(map[string]bool)
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				key := decl.Name.Name
+				if recv := receiverTypeName(decl.Recv); recv != "" {
+					key = recv + "." + key
+				}
+
+				documented[key] = decl.Doc != nil
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					doc := decl.Doc
+					if decl.Lparen.IsValid() {
+						doc = nil
+					}
+
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						if spec.Doc != nil {
+							doc = spec.Doc
+						}
+						documented[spec.Name.Name] = doc != nil
+					case *ast.ValueSpec:
+						if spec.Doc != nil {
+							doc = spec.Doc
+						}
+						for _, name := range spec.Names {
+							documented[name.Name] = doc != nil
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return documented
+}
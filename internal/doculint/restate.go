@@ -0,0 +1,131 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// fillerWords are the words ignored when comparing the words of a comment with the
+// name and signature of its declaration, since they add no information on their own.
+var fillerWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "by": true, "for": true, "from": true, "given": true,
+	"in": true, "is": true, "it": true, "its": true, "of": true, "on": true, "or": true, "provided": true,
+	"specified": true, "that": true, "the": true, "this": true, "to": true, "with": true,
+}
+
+// checkRestated reports the doc comment of a declaration named name and described by
+// what, when -restated-docs is set, if it is a single sentence whose every word is
+// filler or appears in name or the signature of fn, for functions, as in "GetUser gets
+// user", since such comments add no information beyond the declaration.
+func (l *Linter) checkRestated(pass *analysis.Pass, what, name string, pos token.Pos, doc *ast.CommentGroup, fn *ast.FuncDecl) {
+	if !l.requireInformativeDocs {
+		return
+	}
+
+	text := strings.TrimSpace(doc.Text())
+	if countSentences(text) != 1 || indentation(text) > 0 || strings.Contains(text, "\n\n") {
+		return
+	}
+
+	words := identifierWords(name)
+	if fn != nil {
+		words = append(words, signatureWords(fn)...)
+	}
+
+	known := make(map[string]bool, len(words))
+	for _, word := range words {
+		known[stem(word)] = true
+	}
+
+	for _, field := range strings.FieldsFunc(strings.TrimPrefix(text, name), isNotWordRune) {
+		for _, word := range identifierWords(field) {
+			if !fillerWords[word] && !known[stem(word)] {
+				return
+			}
+		}
+	}
+
+	l.report(pass, RuleRestatedComment, pos, "comment for %s only restates its declaration, describe its behavior instead", what)
+}
+
+// signatureWords returns the words of the names and types of the parameters and results
+// of fn, such as "user" and "id" for "func(userID string) *User".
+func signatureWords(fn *ast.FuncDecl) []string {
+	var words []string
+	for _, list := range []*ast.FieldList{fn.Recv, fn.Type.Params, fn.Type.Results} {
+		if list == nil {
+			continue
+		}
+
+		for _, field := range list.List {
+			for _, name := range field.Names {
+				words = append(words, identifierWords(name.Name)...)
+			}
+
+			for _, part := range strings.FieldsFunc(types.ExprString(field.Type), isNotWordRune) {
+				words = append(words, identifierWords(part)...)
+			}
+		}
+	}
+
+	return words
+}
+
+// identifierWords splits the identifier or word s into its lowercase words at case
+// changes, underscores, and digits, such as "get", "http", and "server" for
+// "getHTTPServer".
+func identifierWords(s string) []string {
+	var words []string
+	start := 0
+	prev, size := utf8.DecodeRuneInString(s)
+	for i := size; ; {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		next, _ := utf8.DecodeRuneInString(s[i+n:])
+		boundary := i == len(s) || r == '_' ||
+			unicode.IsUpper(r) && (unicode.IsLower(prev) || i+n < len(s) && unicode.IsLower(next)) ||
+			unicode.IsDigit(r) != unicode.IsDigit(prev)
+		if boundary {
+			if word := strings.Trim(s[start:i], "_"); word != "" {
+				words = append(words, strings.ToLower(word))
+			}
+			start = i
+		}
+
+		if i == len(s) {
+			return words
+		}
+		prev, i = r, i+n
+	}
+}
+
+// stem returns word without a common inflectional suffix, a final "e", or a doubled
+// final letter, such as "get" for "gets" and "getting", or "creat" for "create" and
+// "creates", so that inflections of the same word compare equal.
+func stem(word string) string {
+	for _, suffix := range []string{"ing", "ies", "es", "ed", "s"} {
+		if base, ok := strings.CutSuffix(word, suffix); ok && len(base) >= 3 {
+			if suffix == "ies" {
+				return base + "y"
+			}
+
+			word = base
+			break
+		}
+	}
+
+	if len(word) > 3 {
+		word = strings.TrimSuffix(word, "e")
+	}
+
+	if n := len(word); n > 3 && word[n-1] == word[n-2] {
+		word = word[:n-1]
+	}
+
+	return word
+}
@@ -0,0 +1,404 @@
+package doculint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Confidence describes how likely it is that a finding reported by a rule is a genuine
+// documentation problem rather than a false positive.
+type Confidence int
+
+// Confidence levels, ordered from least to most confident.
+const (
+	// ConfidenceLow is used by heuristics that are noisy but still useful to surface.
+	ConfidenceLow Confidence = iota
+
+	// ConfidenceMedium is used by heuristics that are usually, but not always, right.
+	ConfidenceMedium
+
+	// ConfidenceHigh is used by rules that are mechanical checks of a convention.
+	ConfidenceHigh
+)
+
+// String returns the name of the confidence level, which is also the value accepted by
+// Set.
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	}
+
+	return fmt.Sprintf("Confidence(%d)", int(c))
+}
+
+// Set parses a confidence level from its name, allowing Confidence to be used as a
+// flag.Value.
+func (c *Confidence) Set(s string) error {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low":
+		*c = ConfidenceLow
+	case "medium":
+		*c = ConfidenceMedium
+	case "high":
+		*c = ConfidenceHigh
+	default:
+		return fmt.Errorf("unknown confidence \"%s\", expected one of low, medium, or high", s)
+	}
+
+	return nil
+}
+
+// Rule describes a single check performed by the doculint analyzer.
+type Rule struct {
+	// ID is the stable identifier of the rule. It is used as the category of every
+	// diagnostic reported by the rule.
+	ID string
+
+	// Name is a short, human readable name for the rule.
+	Name string
+
+	// Confidence is how likely a finding reported by the rule is a real problem.
+	// Findings from rules below the analyzer's -min-confidence are not reported.
+	Confidence Confidence
+
+	// Package reports whether the findings of the rule are about the package as a whole.
+	// They are reported at a package clause, but only package suppressions cover them.
+	Package bool
+
+	// DocURL is the URL of the documentation of a rule added by a check, which is not
+	// documented along with the rules of the analyzer.
+	DocURL string
+}
+
+// rulesURL is the URL of the documentation of the rules, with a section per rule.
+const rulesURL = "https://github.com/george-e-shaw-iv/doculint/blob/main/docs/rules.md"
+
+// URL returns the URL of the section of the rules documentation explaining r, with
+// examples of compliant code, or the DocURL of rules added by checks.
+func (r Rule) URL() string {
+	if r.DocURL != "" {
+		return r.DocURL
+	}
+
+	if url, ok := builtinURLs[r]; ok {
+		return url
+	}
+
+	return rulesURL + "#" + strings.ToLower(r.ID) + "-" + r.Name
+}
+
+// builtinURLs maps the rules of the analyzer to their URLs, built once rather than for
+// every diagnostic reported.
+var builtinURLs = func() map[Rule]string {
+	urls := make(map[Rule]string)
+	for _, r := range builtinRules() {
+		urls[r] = rulesURL + "#" + strings.ToLower(r.ID) + "-" + r.Name
+	}
+
+	return urls
+}()
+
+// The rules reported by the doculint analyzer.
+var (
+	// RulePackageName validates package names against the Go conventions.
+	RulePackageName = Rule{ID: "DL001", Name: "package-name", Confidence: ConfidenceHigh, Package: true}
+
+	// RulePackageFile validates that a package has a file named after it.
+	RulePackageFile = Rule{ID: "DL002", Name: "package-file", Confidence: ConfidenceHigh, Package: true}
+
+	// RulePackageComment validates the package comment.
+	RulePackageComment = Rule{ID: "DL003", Name: "package-comment", Confidence: ConfidenceHigh, Package: true}
+
+	// RuleFunctionComment validates function comments.
+	RuleFunctionComment = Rule{ID: "DL004", Name: "function-comment", Confidence: ConfidenceHigh}
+
+	// RuleConstantBlockComment validates constant block comments.
+	RuleConstantBlockComment = Rule{ID: "DL005", Name: "constant-block-comment", Confidence: ConfidenceHigh}
+
+	// RuleConstantComment validates constant comments.
+	RuleConstantComment = Rule{ID: "DL006", Name: "constant-comment", Confidence: ConfidenceHigh}
+
+	// RuleTypeBlockComment validates type block comments.
+	RuleTypeBlockComment = Rule{ID: "DL007", Name: "type-block-comment", Confidence: ConfidenceHigh}
+
+	// RuleTypeComment validates type comments.
+	RuleTypeComment = Rule{ID: "DL008", Name: "type-comment", Confidence: ConfidenceHigh}
+
+	// RuleConditionalLiteral reports literals used in conditional expressions.
+	RuleConditionalLiteral = Rule{ID: "DL009", Name: "conditional-literal", Confidence: ConfidenceHigh}
+
+	// RuleMethodReceiver validates that method comments mention their receiver type.
+	RuleMethodReceiver = Rule{ID: "DL010", Name: "method-receiver", Confidence: ConfidenceMedium}
+
+	// RuleCommentPeriod validates that doc comments end with terminal punctuation.
+	RuleCommentPeriod = Rule{ID: "DL011", Name: "comment-period", Confidence: ConfidenceHigh}
+
+	// RuleProcessExit reports calls that terminate the process in library packages.
+	RuleProcessExit = Rule{ID: "DL012", Name: "process-exit", Confidence: ConfidenceHigh}
+
+	// RuleExitComment validates that library functions that terminate the process
+	// document it.
+	RuleExitComment = Rule{ID: "DL013", Name: "exit-comment", Confidence: ConfidenceMedium}
+
+	// RuleCommentSentence validates that doc comments form complete sentences.
+	RuleCommentSentence = Rule{ID: "DL014", Name: "comment-sentence", Confidence: ConfidenceMedium}
+
+	// RuleDeprecated validates the format of deprecation notices.
+	RuleDeprecated = Rule{ID: "DL015", Name: "deprecated", Confidence: ConfidenceHigh}
+
+	// RuleDocLink validates that doc links resolve to declared identifiers.
+	RuleDocLink = Rule{ID: "DL016", Name: "doc-link", Confidence: ConfidenceHigh}
+
+	// RuleLineLength validates the length of doc comment lines.
+	RuleLineLength = Rule{ID: "DL017", Name: "line-length", Confidence: ConfidenceHigh}
+
+	// RuleCommentMarker reports markers such as TODO in the comments of exported
+	// declarations.
+	RuleCommentMarker = Rule{ID: "DL018", Name: "comment-marker", Confidence: ConfidenceHigh}
+
+	// RuleReadme validates that identifiers referenced in a README exist and are
+	// documented.
+	RuleReadme = Rule{ID: "DL019", Name: "readme", Confidence: ConfidenceMedium}
+
+	// RulePackageCommentLength validates the length of package comments.
+	RulePackageCommentLength = Rule{ID: "DL020", Name: "package-comment-length", Confidence: ConfidenceHigh}
+
+	// RuleDuplicatePackageComment reports packages with a package comment in more than one file.
+	RuleDuplicatePackageComment = Rule{ID: "DL021", Name: "duplicate-package-comment", Confidence: ConfidenceHigh}
+
+	// RuleBlockGrouping validates that large constant and variable blocks are organized in commented groups.
+	RuleBlockGrouping = Rule{ID: "DL022", Name: "block-grouping", Confidence: ConfidenceMedium}
+
+	// RuleExample validates that the exported functions and types of large packages have examples.
+	RuleExample = Rule{ID: "DL023", Name: "example", Confidence: ConfidenceMedium}
+
+	// RuleBannedPhrase reports doc comments containing banned phrases.
+	RuleBannedPhrase = Rule{ID: "DL024", Name: "banned-phrase", Confidence: ConfidenceMedium}
+
+	// RuleErrorSentinel validates the documentation of error sentinels such as ErrNotFound.
+	RuleErrorSentinel = Rule{ID: "DL025", Name: "error-sentinel", Confidence: ConfidenceHigh}
+
+	// RuleIotaEnum validates that the comments of iota enum blocks describe the enum.
+	RuleIotaEnum = Rule{ID: "DL026", Name: "iota-enum", Confidence: ConfidenceMedium}
+
+	// RuleFunctionVerb validates that function comments continue with a present tense verb after the name of the function.
+	RuleFunctionVerb = Rule{ID: "DL027", Name: "function-verb", Confidence: ConfidenceLow}
+
+	// RuleSpelling reports likely misspellings in the comments of exported declarations.
+	RuleSpelling = Rule{ID: "DL028", Name: "spelling", Confidence: ConfidenceMedium}
+
+	// RuleLineComment validates that declaration doc comments are line comments.
+	RuleLineComment = Rule{ID: "DL029", Name: "line-comment", Confidence: ConfidenceHigh}
+
+	// RuleDetachedComment reports doc comments separated from their declaration by a blank line.
+	RuleDetachedComment = Rule{ID: "DL030", Name: "detached-comment", Confidence: ConfidenceHigh}
+
+	// RuleParamReference validates that the identifiers referenced in function comments are parameters, results, or receivers of the function.
+	RuleParamReference = Rule{ID: "DL031", Name: "param-reference", Confidence: ConfidenceMedium}
+
+	// RulePanicComment validates that exported functions that can panic document it.
+	RulePanicComment = Rule{ID: "DL032", Name: "panic-comment", Confidence: ConfidenceMedium}
+
+	// RuleTypeParamComment validates that the comments of generic functions and types mention each of their type parameters.
+	RuleTypeParamComment = Rule{ID: "DL033", Name: "type-param-comment", Confidence: ConfidenceMedium}
+
+	// RuleEmbeddedComment validates that the fields embedded in exported structs have comments.
+	RuleEmbeddedComment = Rule{ID: "DL034", Name: "embedded-comment", Confidence: ConfidenceMedium}
+
+	// RuleTypeAlias validates that the comments of type aliases explain the aliasing.
+	RuleTypeAlias = Rule{ID: "DL035", Name: "type-alias", Confidence: ConfidenceMedium}
+
+	// RuleCgoExport validates the comments of functions exported to C with //export directives.
+	RuleCgoExport = Rule{ID: "DL036", Name: "cgo-export", Confidence: ConfidenceHigh}
+
+	// RuleGenerateComment validates that //go:generate directives are preceded by a comment explaining them.
+	RuleGenerateComment = Rule{ID: "DL037", Name: "generate-comment", Confidence: ConfidenceMedium}
+
+	// RuleBuildConstraint validates that files with build constraints explain them.
+	RuleBuildConstraint = Rule{ID: "DL038", Name: "build-constraint-comment", Confidence: ConfidenceMedium}
+
+	// RuleStutter validates that exported identifiers do not repeat the package name.
+	RuleStutter = Rule{ID: "DL039", Name: "stutter", Confidence: ConfidenceMedium}
+
+	// RulePackageNameQuality validates that package names are short and meaningful.
+	RulePackageNameQuality = Rule{ID: "DL040", Name: "package-name-quality", Confidence: ConfidenceMedium, Package: true}
+
+	// RulePackageNamePlural validates that package names are singular.
+	RulePackageNamePlural = Rule{ID: "DL041", Name: "package-name-plural", Confidence: ConfidenceLow, Package: true}
+
+	// RuleStdlibShadow validates that package names do not shadow the standard library.
+	RuleStdlibShadow = Rule{ID: "DL042", Name: "package-name-stdlib", Confidence: ConfidenceMedium, Package: true}
+
+	// RuleTestFunctionComment validates that benchmarks and fuzz tests document what they exercise.
+	RuleTestFunctionComment = Rule{ID: "DL043", Name: "test-function-comment", Confidence: ConfidenceMedium}
+
+	// RuleMultiSentence validates that complex exported functions have more than a one sentence comment.
+	RuleMultiSentence = Rule{ID: "DL044", Name: "multi-sentence", Confidence: ConfidenceMedium}
+
+	// RuleRestatedComment validates that comments add information beyond the name and signature of their declaration.
+	RuleRestatedComment = Rule{ID: "DL045", Name: "restated-comment", Confidence: ConfidenceLow}
+
+	// RuleDocSyntax validates that doc comments render as intended on pkg.go.dev.
+	RuleDocSyntax = Rule{ID: "DL046", Name: "doc-syntax", Confidence: ConfidenceMedium}
+
+	// RuleDocBlock validates that code blocks and lists in doc comments are indented.
+	RuleDocBlock = Rule{ID: "DL047", Name: "doc-block", Confidence: ConfidenceMedium}
+
+	// RuleMagicLiteral reports numeric literals used as function arguments and return values.
+	RuleMagicLiteral = Rule{ID: "DL048", Name: "magic-literal", Confidence: ConfidenceLow}
+
+	// RuleNolintReason validates that //nolint comments explain why issues are suppressed.
+	RuleNolintReason = Rule{ID: "DL049", Name: "nolint-reason", Confidence: ConfidenceHigh}
+
+	// RuleConfigField validates that the fields of configuration structs are documented along with their default values.
+	RuleConfigField = Rule{ID: "DL050", Name: "config-field", Confidence: ConfidenceMedium}
+
+	// RuleDocLanguage validates that the comments of exported declarations are written in the configured language.
+	RuleDocLanguage = Rule{ID: "DL051", Name: "doc-language", Confidence: ConfidenceLow}
+
+	// RuleGlossary validates that doc comments use the preferred terms of the glossary in the configuration file.
+	RuleGlossary = Rule{ID: "DL052", Name: "glossary", Confidence: ConfidenceMedium}
+
+	// RuleDocURL validates that the URLs in doc comments are well formed and, optionally, reachable.
+	RuleDocURL = Rule{ID: "DL053", Name: "doc-url", Confidence: ConfidenceMedium}
+
+	// RuleFileHeader validates that files begin with the license or copyright header given by -header.
+	RuleFileHeader = Rule{ID: "DL054", Name: "file-header", Confidence: ConfidenceHigh}
+
+	// RuleErrorComment validates that exported functions returning an error document when they do.
+	RuleErrorComment = Rule{ID: "DL055", Name: "error-comment", Confidence: ConfidenceMedium}
+
+	// RuleConstructorComment validates that the comments of NewXxx constructors mention the type they return.
+	RuleConstructorComment = Rule{ID: "DL056", Name: "constructor-comment", Confidence: ConfidenceMedium}
+
+	// RuleAccessorComment validates that the comments of paired getters and setters do more than echo their names.
+	RuleAccessorComment = Rule{ID: "DL057", Name: "accessor-comment", Confidence: ConfidenceLow}
+
+	// RuleInterfaceContract validates that the comments of exported interfaces describe the contract of their implementations.
+	RuleInterfaceContract = Rule{ID: "DL058", Name: "interface-contract", Confidence: ConfidenceMedium}
+
+	// RuleDuplicateComment reports declarations sharing the same doc comment, usually the sign of a stale copy.
+	RuleDuplicateComment = Rule{ID: "DL059", Name: "duplicate-comment", Confidence: ConfidenceLow}
+
+	// RuleStaleReference reports doc comments referring to identifiers, fields, or methods that are not declared.
+	RuleStaleReference = Rule{ID: "DL060", Name: "stale-reference", Confidence: ConfidenceLow}
+
+	// RuleWellKnownMethod validates that the comments of well-known interface methods, such as String, mention the interface they implement.
+	RuleWellKnownMethod = Rule{ID: "DL061", Name: "well-known-method", Confidence: ConfidenceLow}
+
+	// RuleTaggedFieldComment validates that the struct fields serialized through tags such as json are documented.
+	RuleTaggedFieldComment = Rule{ID: "DL062", Name: "tagged-field-comment", Confidence: ConfidenceMedium}
+
+	// RuleUpstreamComment reports exported declarations re-exporting or wrapping undocumented declarations of other packages.
+	RuleUpstreamComment = Rule{ID: "DL063", Name: "upstream-comment", Confidence: ConfidenceMedium}
+)
+
+// builtinRulesByID maps the IDs of the rules of the analyzer to the rules, built once
+// rather than for every lookup.
+var builtinRulesByID = func() map[string]Rule {
+	rules := make(map[string]Rule)
+	for _, r := range builtinRules() {
+		rules[r.ID] = r
+	}
+
+	return rules
+}()
+
+// Rules returns every rule known to l, ordered by ID, followed by the rules of its
+// checks: the registered ones and those defined by its configuration file, if it could
+// be loaded. It must only be called once the flags of l are set.
+func (l *Linter) Rules() []Rule {
+	rules := builtinRules()
+	l.loadChecks()
+	for _, c := range l.checks.list {
+		rules = append(rules, c.Rule())
+	}
+
+	return rules
+}
+
+// builtinRules returns the rules of the analyzer itself, ordered by ID.
+func builtinRules() []Rule {
+	return []Rule{
+		RulePackageName,
+		RulePackageFile,
+		RulePackageComment,
+		RuleFunctionComment,
+		RuleConstantBlockComment,
+		RuleConstantComment,
+		RuleTypeBlockComment,
+		RuleTypeComment,
+		RuleConditionalLiteral,
+		RuleMethodReceiver,
+		RuleCommentPeriod,
+		RuleProcessExit,
+		RuleExitComment,
+		RuleCommentSentence,
+		RuleDeprecated,
+		RuleDocLink,
+		RuleLineLength,
+		RuleCommentMarker,
+		RuleReadme,
+		RulePackageCommentLength,
+		RuleDuplicatePackageComment,
+		RuleBlockGrouping,
+		RuleExample,
+		RuleBannedPhrase,
+		RuleErrorSentinel,
+		RuleIotaEnum,
+		RuleFunctionVerb,
+		RuleSpelling,
+		RuleLineComment,
+		RuleDetachedComment,
+		RuleParamReference,
+		RulePanicComment,
+		RuleTypeParamComment,
+		RuleEmbeddedComment,
+		RuleTypeAlias,
+		RuleCgoExport,
+		RuleGenerateComment,
+		RuleBuildConstraint,
+		RuleStutter,
+		RulePackageNameQuality,
+		RulePackageNamePlural,
+		RuleStdlibShadow,
+		RuleTestFunctionComment,
+		RuleMultiSentence,
+		RuleRestatedComment,
+		RuleDocSyntax,
+		RuleDocBlock,
+		RuleMagicLiteral,
+		RuleNolintReason,
+		RuleConfigField,
+		RuleDocLanguage,
+		RuleGlossary,
+		RuleDocURL,
+		RuleFileHeader,
+		RuleErrorComment,
+		RuleConstructorComment,
+		RuleAccessorComment,
+		RuleInterfaceContract,
+		RuleDuplicateComment,
+		RuleStaleReference,
+		RuleWellKnownMethod,
+		RuleTaggedFieldComment,
+		RuleUpstreamComment,
+	}
+}
+
+// LookupRule returns the rule of l with the given ID, which is also the category of the
+// diagnostics it reports. It must only be called once the flags of l are set.
+func (l *Linter) LookupRule(id string) (Rule, bool) {
+	if rule, ok := builtinRulesByID[id]; ok {
+		return rule, true
+	}
+
+	l.loadChecks()
+	rule, ok := l.checks.rules[id]
+	return rule, ok
+}
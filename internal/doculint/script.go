@@ -0,0 +1,205 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"golang.org/x/tools/go/analysis"
+)
+
+// scriptRule is a rule defined in the configuration file, reporting the declarations
+// for which a CEL expression holds.
+type scriptRule struct {
+	// ID is the ID of the rule, which must not be the ID of another rule.
+	ID string `json:"id"`
+
+	// Name is the short, human readable name of the rule.
+	Name string `json:"name"`
+
+	// Confidence is the confidence of the rule, medium if empty.
+	Confidence string `json:"confidence"`
+
+	// Report is the CEL expression evaluated for every declaration, reporting it if true.
+	Report string `json:"report"`
+
+	// Message is the message of the issues of the rule, in which {kind} and {name} are
+	// replaced with the kind and name of the declaration reported.
+	Message string `json:"message"`
+
+	// URL is the URL of the documentation of the rule, if any.
+	URL string `json:"url"`
+}
+
+// scriptEnv returns the CEL environment rule expressions are compiled in, which declares
+// the variables describing the declaration evaluated and the functions of the strings
+// extension of cel-go, such as lowerAscii, along with the standard ones.
+var scriptEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("kind", cel.StringType),
+		cel.Variable("name", cel.StringType),
+		cel.Variable("exported", cel.BoolType),
+		cel.Variable("documented", cel.BoolType),
+		cel.Variable("doc", cel.StringType),
+		cel.Variable("receiver", cel.StringType),
+		cel.Variable("file", cel.StringType),
+		cel.Variable("test", cel.BoolType),
+		cel.Variable("pkg", cel.StringType),
+		ext.Strings(),
+	)
+})
+
+// scriptCheck is the check of a rule defined in the configuration file.
+type scriptCheck struct {
+	// rule is the rule the issues of the check are reported under.
+	rule Rule
+
+	// program is the compiled expression of the rule.
+	program cel.Program
+
+	// message is the message of the issues of the rule, with placeholders.
+	message string
+}
+
+// compileScriptRule compiles the expression of sr, returning its check. Expressions that
+// do not parse, type check, or evaluate to a bool are errors, as are literal patterns
+// of matches that do not compile.
+func compileScriptRule(sr scriptRule) (*scriptCheck, error) {
+	if sr.ID == "" || sr.Report == "" {
+		return nil, fmt.Errorf("rule %q: id and report are required", sr.ID)
+	}
+
+	c := &scriptCheck{
+		rule:    Rule{ID: sr.ID, Name: sr.Name, Confidence: ConfidenceMedium, DocURL: sr.URL},
+		message: sr.Message,
+	}
+
+	if c.rule.Name == "" {
+		c.rule.Name = strings.ToLower(sr.ID)
+	}
+
+	if c.message == "" {
+		c.message = fmt.Sprintf("{kind} \"{name}\" is reported by rule %s", sr.ID)
+	}
+
+	if sr.Confidence != "" {
+		if err := c.rule.Confidence.Set(sr.Confidence); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", sr.ID, err)
+		}
+	}
+
+	env, err := scriptEnv()
+	if err != nil {
+		return nil, fmt.Errorf("rule %s: %w", sr.ID, err)
+	}
+
+	compiled, issues := env.Compile(sr.Report)
+	if err := issues.Err(); err != nil {
+		return nil, fmt.Errorf("rule %s: %w", sr.ID, err)
+	}
+	if compiled.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("rule %s: report is of type %s, expected bool", sr.ID, compiled.OutputType())
+	}
+
+	// Optimizing evaluates the constant parts of the expression once, compiling its
+	// literal patterns along the way.
+	c.program, err = env.Program(compiled, cel.EvalOptions(cel.OptOptimize))
+	if err != nil {
+		return nil, fmt.Errorf("rule %s: %w", sr.ID, err)
+	}
+
+	return c, nil
+}
+
+// Rule returns the rule of the check.
+func (c *scriptCheck) Rule() Rule {
+	return c.rule
+}
+
+// Nodes returns the nodes holding declarations, which the check evaluates its
+// expression for.
+func (c *scriptCheck) Nodes() []ast.Node {
+	return []ast.Node{(*ast.File)(nil), (*ast.FuncDecl)(nil), (*ast.GenDecl)(nil)}
+}
+
+// Run evaluates the expression of the check for the declarations of node, reporting
+// those for which it holds. A package is evaluated at the package clause of the file
+// holding its comment, or of its first file if it has none.
+func (c *scriptCheck) Run(pass *analysis.Pass, node ast.Node, report ReportFunc) {
+	file := filepath.Base(pass.Fset.Position(node.Pos()).Filename)
+
+	eval := func(kind string, name *ast.Ident, receiver string, doc *ast.CommentGroup) {
+		out, _, err := c.program.Eval(map[string]interface{}{
+			"kind":       kind,
+			"name":       name.Name,
+			"exported":   name.IsExported() || kind == kindPackage,
+			"documented": doc != nil,
+			"doc":        doc.Text(),
+			"receiver":   receiver,
+			"file":       file,
+			"test":       strings.HasSuffix(file, "_test.go"),
+			"pkg":        pass.Pkg.Name(),
+		})
+		if err != nil {
+			report(name, "rule %s cannot be evaluated for %s \"%s\": %v", c.rule.ID, kind, name.Name, err)
+			return
+		}
+
+		if holds, _ := out.Value().(bool); holds {
+			message := strings.NewReplacer("{kind}", kind, "{name}", name.Name).Replace(c.message)
+			report(name, "%s", message)
+		}
+	}
+
+	switch n := node.(type) {
+	case *ast.File:
+		if n.Doc != nil || (!packageDocumented(pass) && n == pass.Files[0]) {
+			eval(kindPackage, n.Name, "", n.Doc)
+		}
+	case *ast.FuncDecl:
+		eval(kindFunction, n.Name, receiverTypeName(n.Recv), n.Doc)
+	case *ast.GenDecl:
+		kind := map[token.Token]string{token.TYPE: kindType, token.CONST: kindConstant, token.VAR: kindVariable}[n.Tok]
+		if kind == "" {
+			return
+		}
+
+		for _, spec := range n.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				eval(kind, s.Name, "", declDoc(n, s.Doc))
+			case *ast.ValueSpec:
+				for _, name := range s.Names {
+					eval(kind, name, "", declDoc(n, s.Doc))
+				}
+			}
+		}
+	}
+}
+
+// packageDocumented reports whether a file of the package analyzed by pass has a
+// package comment.
+func packageDocumented(pass *analysis.Pass) bool {
+	for _, file := range pass.Files {
+		if file.Doc != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// declDoc returns the comment of a spec of decl, doc, or the comment of decl if it is
+// not a block.
+func declDoc(decl *ast.GenDecl, doc *ast.CommentGroup) *ast.CommentGroup {
+	if !decl.Lparen.IsValid() {
+		return decl.Doc
+	}
+
+	return doc
+}
@@ -0,0 +1,259 @@
+package doculint
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// builtinWords is the built-in word list of the spellchecker, one lower case word per
+// line. It is curated by hand from the words of the comments of the Go standard library,
+// leaving out misspellings, identifiers, and names, which are listed in words_rejected.txt,
+// and is extended with the candidates printed by make word-candidates once they are
+// reviewed.
+//
+//go:embed words.txt
+var builtinWords string
+
+// maxSuggestions is the maximum number of corrections suggested for a misspelled word.
+const maxSuggestions = 3
+
+// minSpelledWordLength is the minimum length of the words that are spellchecked, since
+// short words are too often abbreviations.
+const minSpelledWordLength = 4
+
+// spellWordPattern matches the words of a comment, capturing each word without the
+// character preceding it, which must not be part of an identifier, path, or word with
+// an apostrophe.
+var spellWordPattern = regexp.MustCompile(`(?:^|[^\w'./-])([A-Za-z]+)`)
+
+// spellMaskPatterns match the parts of a comment that are not prose and are therefore not
+// spellchecked, such as code spans, doc links, and URLs.
+var spellMaskPatterns = []*regexp.Regexp{
+	regexp.MustCompile("`[^`]*`"),
+	regexp.MustCompile(`\[[^\]]*\]`),
+	regexp.MustCompile(`\S+://\S+`),
+}
+
+// wordSuffixes are the suffixes removed from words that are not in the dictionary to
+// find their base form, such as "parses" for "parse".
+var wordSuffixes = []string{"'s", "s", "es", "d", "ed", "ing", "ly", "er", "ers"}
+
+// loadDictionary returns the words known to the spellchecker, reading the -dictionary
+// file the first time it is called.
+func (l *Linter) loadDictionary() (map[string]bool, error) {
+	l.dictionary.once.Do(func() {
+		l.dictionary.words = make(map[string]bool)
+		for _, word := range strings.Fields(builtinWords) {
+			l.dictionary.words[word] = true
+		}
+
+		if l.dictionaryPath == "" {
+			return
+		}
+
+		data, err := os.ReadFile(l.dictionaryPath)
+		if err != nil {
+			l.dictionary.err = fmt.Errorf("read dictionary: %w", err)
+			return
+		}
+
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				l.dictionary.words[strings.ToLower(line)] = true
+			}
+		}
+	})
+
+	return l.dictionary.words, l.dictionary.err
+}
+
+// checkSpelling reports the likely misspelled words in the doc comment of a declaration
+// described by what, if -spelling is set. A word is likely misspelled if it is not
+// known, but a known word is a single edit away from it. Unknown words without such a
+// correction, such as jargon, are not reported, and neither are the name of the
+// declaration and capitalized words within sentences, which are usually identifiers or
+// proper nouns. Diagnostics suggesting a single correction carry a fix applying it.
+func (l *Linter) checkSpelling(pass *analysis.Pass, what, name string, doc *ast.CommentGroup) {
+	if !l.checkSpell {
+		return
+	}
+
+	words, err := l.loadDictionary()
+	if err != nil {
+		// The error is returned by the analyzer before any comment is checked.
+		return
+	}
+
+	for _, c := range doc.List {
+		if isDirective(c.Text) || strings.HasPrefix(c.Text, "//\t") || strings.HasPrefix(c.Text, "//  ") {
+			// Directives and code blocks are not prose.
+			continue
+		}
+
+		text := c.Text
+		for _, pattern := range spellMaskPatterns {
+			text = pattern.ReplaceAllStringFunc(text, func(s string) string {
+				return strings.Repeat(" ", len(s))
+			})
+		}
+
+		for _, m := range spellWordPattern.FindAllStringSubmatchIndex(text, -1) {
+			start, end := m[2], m[3]
+			if end < len(text) && strings.ContainsRune("_./-('", rune(text[end])) {
+				// Identifiers, paths, calls, and words with an apostrophe, such as
+				// contractions, are not checked.
+				continue
+			}
+
+			word := c.Text[start:end]
+			if word == name || unicode.IsUpper(rune(word[0])) && !startsSentence(text[:start], c == doc.List[0]) {
+				continue
+			}
+
+			l.checkWord(pass, words, what, c.Pos()+token.Pos(start), word)
+		}
+	}
+}
+
+// startsSentence reports whether a word preceded by the given text of its comment,
+// including the comment markers, starts a sentence, meaning the text is terminal
+// punctuation or the markers of the first comment of a group.
+func startsSentence(before string, first bool) bool {
+	before = strings.TrimRight(before, " \t")
+	if first && (before == "//" || before == "/*") {
+		return true
+	}
+
+	return before != "" && strings.ContainsRune(terminalPunctuation, rune(before[len(before)-1]))
+}
+
+// checkWord reports word, found at pos in the doc comment of a declaration described by
+// what, if it is likely misspelled.
+func (l *Linter) checkWord(pass *analysis.Pass, words map[string]bool, what string, pos token.Pos, word string) {
+	if len(word) < minSpelledWordLength || strings.IndexFunc(word[1:], unicode.IsUpper) >= 0 {
+		// Short words, acronyms, and identifiers in camel case are not spellchecked.
+		return
+	}
+
+	lower := strings.ToLower(word)
+	if words[lower] || pass.Pkg.Scope().Lookup(word) != nil {
+		return
+	}
+
+	suggestions := spellingSuggestions(words, lower)
+	if base, suffix, ok := knownBase(words, lower); ok {
+		// Inflections of known words are only reported when they are a single edit
+		// away from the same inflection spelled correctly, as in "occured".
+		var inflections []string
+		for _, s := range suggestions {
+			if strings.HasPrefix(s, base) && strings.HasSuffix(s, suffix) {
+				inflections = append(inflections, s)
+			}
+		}
+		suggestions = inflections
+	}
+
+	if len(suggestions) == 0 {
+		return
+	}
+
+	if unicode.IsUpper(rune(word[0])) {
+		for i := range suggestions {
+			suggestions[i] = strings.ToUpper(suggestions[i][:1]) + suggestions[i][1:]
+		}
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     pos,
+		End:     pos + token.Pos(len(word)),
+		Message: fmt.Sprintf("\"%s\" in comment for %s is likely misspelled, did you mean \"%s\"?", word, what, strings.Join(suggestions, "\" or \"")),
+	}
+
+	if len(suggestions) == 1 {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: fmt.Sprintf("Replace with \"%s\"", suggestions[0]),
+			TextEdits: []analysis.TextEdit{{
+				Pos:     diag.Pos,
+				End:     diag.End,
+				NewText: []byte(suggestions[0]),
+			}},
+		}}
+	}
+
+	l.reportDiagnostic(pass, RuleSpelling, diag)
+}
+
+// knownBase returns the base form of the lower case word and the suffix removed from it
+// to find a base form in words, such as "parse" and "es" for "parses", or false if the
+// word has no known base form.
+func knownBase(words map[string]bool, word string) (string, string, bool) {
+	for _, suffix := range wordSuffixes {
+		base, ok := strings.CutSuffix(word, suffix)
+		if !ok || base == "" {
+			continue
+		}
+
+		if words[base] || words[base+"e"] {
+			return base, suffix, true
+		}
+
+		// Doubled final consonants, as in "stopped".
+		if n := len(base); n > 1 && base[n-1] == base[n-2] && words[base[:n-1]] {
+			return base, suffix, true
+		}
+	}
+
+	return "", "", false
+}
+
+// spellingSuggestions returns up to maxSuggestions words that are a single deletion,
+// insertion, substitution, or transposition away from the lower case word, sorted.
+func spellingSuggestions(words map[string]bool, word string) []string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+
+	found := make(map[string]bool)
+	add := func(candidate string) {
+		if candidate != word && words[candidate] {
+			found[candidate] = true
+		}
+	}
+
+	for i := 0; i <= len(word); i++ {
+		if i < len(word) {
+			add(word[:i] + word[i+1:])
+		}
+
+		if i+1 < len(word) {
+			add(word[:i] + string(word[i+1]) + string(word[i]) + word[i+2:])
+		}
+
+		for _, r := range letters {
+			add(word[:i] + string(r) + word[i:])
+
+			if i < len(word) {
+				add(word[:i] + string(r) + word[i+1:])
+			}
+		}
+	}
+
+	suggestions := make([]string, 0, len(found))
+	for candidate := range found {
+		suggestions = append(suggestions, candidate)
+	}
+	sort.Strings(suggestions)
+
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+
+	return suggestions
+}
@@ -0,0 +1,147 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// selectorPattern matches the references to fields, methods, and declarations of
+// imported packages in comment text, such as Client.Do or http.Handler, capturing the
+// qualifier and the selected name.
+var selectorPattern = regexp.MustCompile(`(?:^|[^\w./])([A-Za-z_]\w*)\.([A-Za-z_]\w*)`)
+
+// camelCasePattern matches the words of comment text written in lower camel case, such
+// as parseHeader, which are identifiers rather than prose, capturing the word. Words
+// ending in an acronym, such as gRPC or macOS, are not matched.
+var camelCasePattern = regexp.MustCompile(`(?:^|[^\w./])([a-z][a-z0-9]*[A-Z]+[a-z]\w*)`)
+
+// staleMaskPatterns match the parts of comment text whose identifiers are not checked
+// for staleness, which are doc links, validated by -doc-links, and URLs.
+var staleMaskPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\[[^\]]*\]`),
+	regexp.MustCompile(`\S+://\S+`),
+}
+
+// checkStaleReferences reports the identifiers referenced in the doc comment doc of a
+// declaration described by what that are not declared, when -stale-refs is set, since
+// they are usually left over from renaming or removing them. The references checked are
+// selectors, such as Client.Do or http.Handler, whose qualifier is a type of the package
+// or an import of the file, and words in lower camel case, such as parseHeader. The
+// names in local, such as the parameters of a function, and the fields and methods of
+// owner, the type declared or the receiver of a method if not nil, are declared.
+func (l *Linter) checkStaleReferences(pass *analysis.Pass, what string, pos token.Pos, doc *ast.CommentGroup, local map[string]bool, owner types.Type) {
+	if !l.checkStaleRefs {
+		return
+	}
+
+	imports := fileImports(pass, pos)
+	declared := func(name string) bool {
+		if local[name] || imports[name] != "" || pass.Pkg.Scope().Lookup(name) != nil || types.Universe.Lookup(name) != nil {
+			return true
+		}
+
+		if owner != nil {
+			obj, _, _ := types.LookupFieldOrMethod(owner, true, pass.Pkg, name)
+			return obj != nil
+		}
+
+		return false
+	}
+
+	reported := make(map[string]bool)
+	stale := func(reference, reason string) {
+		if !reported[reference] {
+			reported[reference] = true
+			l.report(pass, RuleStaleReference, pos, "comment for %s refers to \"%s\", %s", what, reference, reason)
+		}
+	}
+
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		if indentation(line) > 0 {
+			// Code blocks may refer to anything.
+			continue
+		}
+
+		line = strings.ReplaceAll(line, "`", " ")
+		for _, pattern := range staleMaskPatterns {
+			line = pattern.ReplaceAllStringFunc(line, func(s string) string {
+				return strings.Repeat(" ", len(s))
+			})
+		}
+
+		for _, m := range selectorPattern.FindAllStringSubmatchIndex(line, -1) {
+			if continuesPath(line[m[1]:]) {
+				// Paths and host names, such as golang.org/x/tools.
+				continue
+			}
+
+			qualifier, name := line[m[2]:m[3]], line[m[4]:m[5]]
+			if path := imports[qualifier]; path != "" {
+				for _, imported := range pass.Pkg.Imports() {
+					if imported.Path() == path && imported.Scope().Lookup(name) == nil {
+						stale(qualifier+"."+name, "but package "+qualifier+" declares no "+name)
+					}
+				}
+				continue
+			}
+
+			if obj, ok := pass.Pkg.Scope().Lookup(qualifier).(*types.TypeName); ok {
+				if field, _, _ := types.LookupFieldOrMethod(obj.Type(), true, pass.Pkg, name); field == nil {
+					stale(qualifier+"."+name, "but "+qualifier+" has no field or method "+name)
+				}
+				continue
+			}
+
+			if local[qualifier] {
+				// Fields and methods of parameters are not resolved.
+				continue
+			}
+
+			if len(qualifier) > 1 && unicode.IsUpper(rune(qualifier[0])) && unicode.IsUpper(rune(name[0])) && !declared(qualifier) {
+				stale(qualifier+"."+name, "but "+qualifier+" is not declared")
+			}
+		}
+
+		for _, m := range camelCasePattern.FindAllStringSubmatchIndex(line, -1) {
+			if continuesPath(line[m[1]:]) {
+				// Paths and the qualifiers of selectors, checked above.
+				continue
+			}
+
+			if word := line[m[2]:m[3]]; !declared(word) {
+				stale(word, "which is not declared")
+			}
+		}
+	}
+}
+
+// continuesPath reports whether rest, the text following a word, continues a path or
+// host name, such as "/x/tools" or ".org", rather than ending a sentence.
+func continuesPath(rest string) bool {
+	return strings.HasPrefix(rest, "/") || len(rest) > 1 && rest[0] == '.' && !isNotWordRune(rune(rest[1]))
+}
+
+// funcLocalNames returns the names of the parameters, results, receiver, and type
+// parameters of fn, along with the name of fn itself.
+func funcLocalNames(fn *ast.FuncDecl) map[string]bool {
+	names := map[string]bool{fn.Name.Name: true}
+	for _, list := range []*ast.FieldList{fn.Recv, fn.Type.TypeParams, fn.Type.Params, fn.Type.Results} {
+		if list == nil {
+			continue
+		}
+
+		for _, field := range list.List {
+			for _, name := range field.Names {
+				names[name.Name] = true
+			}
+		}
+	}
+
+	return names
+}
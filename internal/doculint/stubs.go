@@ -0,0 +1,171 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// stubKinds are the declaration kinds whose missing comments can be fixed by inserting
+// a stub generated from a template of the configuration file.
+var stubKinds = []string{kindPackage, kindFunction, kindType, kindConstant}
+
+// defaultStubs are the templates of the stubs inserted for the declaration kinds the
+// configuration file has no stub for. They hold a sentence following the conventions
+// checked by the analyzer, and no marker such as TODO, so that the comments they
+// insert cause no findings of their own.
+var defaultStubs = map[string]*template.Template{
+	kindPackage:  template.Must(template.New(kindPackage).Parse(`// Package {{.Name}} holds the declarations of the {{.Name}} package.`)),
+	kindFunction: template.Must(template.New(kindFunction).Parse(`// {{.Name}} is a {{if .Receiver}}method of {{.Receiver}}{{else}}function of package {{.Package}}{{end}}.`)),
+	kindType:     template.Must(template.New(kindType).Parse(`// {{.Name}} is a type of package {{.Package}}.`)),
+	kindConstant: template.Must(template.New(kindConstant).Parse(`// {{.Name}} is a constant of package {{.Package}}.`)),
+}
+
+// stubData is the data the stub templates of the configuration file are executed with.
+type stubData struct {
+	// Name is the name of the declaration, or of the package.
+	Name string
+
+	// Kind is the kind of the declaration: package, function, type, or constant.
+	Kind string
+
+	// Receiver is the name of the receiver type of methods, empty otherwise.
+	Receiver string
+
+	// Package is the name of the package of the declaration.
+	Package string
+}
+
+// compileStubs parses the stub templates of the configuration file, keyed by
+// declaration kind, and verifies that they generate line comments.
+func compileStubs(stubs map[string]string) (map[string]*template.Template, error) {
+	kinds := make([]string, 0, len(stubs))
+	for kind := range stubs {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	compiled := make(map[string]*template.Template, len(stubs))
+	for _, kind := range kinds {
+		if !contains(stubKinds, kind) {
+			return nil, fmt.Errorf("stub for unknown declaration kind \"%s\", expected one of %s", kind, strings.Join(stubKinds, ", "))
+		}
+
+		tmpl, err := template.New(kind).Option("missingkey=error").Parse(stubs[kind])
+		if err != nil {
+			return nil, fmt.Errorf("stub for %s: %w", kind, err)
+		}
+
+		if _, err := executeStub(tmpl, stubData{Name: "Name", Kind: kind, Package: "pkg"}); err != nil {
+			return nil, fmt.Errorf("stub for %s: %w", kind, err)
+		}
+
+		compiled[kind] = tmpl
+	}
+
+	return compiled, nil
+}
+
+// executeStub executes tmpl with data and returns the lines of the generated comment,
+// each of which must be a line comment.
+func executeStub(tmpl *template.Template, data stubData) ([]string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "//") {
+			return nil, fmt.Errorf("generated line \"%s\" is not a line comment beginning with //", line)
+		}
+	}
+
+	return lines, nil
+}
+
+// reportMissingDoc reports a diagnostic for the given rule spanning rng, a declaration
+// described by data that has no comment, along with a fix inserting the stub of its
+// kind before pos, the start of the declaration.
+func (l *Linter) reportMissingDoc(pass *analysis.Pass, rule Rule, rng analysis.Range, pos token.Pos, data stubData, format string, args ...interface{}) {
+	diag := analysis.Diagnostic{
+		Pos:     rng.Pos(),
+		End:     rng.End(),
+		Message: fmt.Sprintf(format, args...),
+	}
+
+	if fix, ok := l.stubFix(pass, pos, data); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+	}
+
+	l.reportDiagnostic(pass, rule, diag)
+}
+
+// stubFix returns a fix inserting the stub generated for the declaration described by
+// data before pos, indented as the declaration is, from the stub of the configuration
+// file for the kind of the declaration, or the default one if it has none. No fix is
+// returned if the declaration does not begin its line, or if it has a detached comment,
+// which the fix of RuleDetachedComment attaches to it instead.
+func (l *Linter) stubFix(pass *analysis.Pass, pos token.Pos, data stubData) (analysis.SuggestedFix, bool) {
+	prefix := data.Name
+	if data.Kind == kindPackage {
+		prefix = "Package " + data.Name
+	}
+	if hasDetachedComment(pass, pos, prefix) {
+		return analysis.SuggestedFix{}, false
+	}
+
+	cfg, err := l.loadConfig()
+	if err != nil {
+		return analysis.SuggestedFix{}, false
+	}
+
+	tmpl := cfg.stubs[data.Kind]
+	if tmpl == nil {
+		tmpl = defaultStubs[data.Kind]
+	}
+
+	data.Package = pass.Pkg.Name()
+	lines, err := executeStub(tmpl, data)
+	if err != nil {
+		return analysis.SuggestedFix{}, false
+	}
+
+	tf := pass.Fset.File(pos)
+	src, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return analysis.SuggestedFix{}, false
+	}
+
+	offset := tf.Offset(pos)
+	lineStart := tf.Offset(tf.LineStart(tf.Line(pos)))
+	if offset > len(src) || strings.Trim(string(src[lineStart:offset]), " \t") != "" {
+		return analysis.SuggestedFix{}, false
+	}
+	indent := string(src[lineStart:offset])
+
+	return analysis.SuggestedFix{
+		Message: fmt.Sprintf("Insert a comment stub for %s \"%s\"", data.Kind, data.Name),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     pos,
+			End:     pos,
+			NewText: []byte(strings.Join(lines, "\n"+indent) + "\n" + indent),
+		}},
+	}, true
+}
+
+// declStart returns the position before which the comment of the specification spec of
+// decl is inserted: the keyword of decl if it declares a single specification without
+// parentheses, and the start of spec otherwise.
+func declStart(decl *ast.GenDecl, spec ast.Spec) token.Pos {
+	if !decl.Lparen.IsValid() {
+		return decl.Pos()
+	}
+
+	return spec.Pos()
+}
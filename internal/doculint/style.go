@@ -0,0 +1,165 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// starPrefix matches the leading asterisk of the lines of block comments written in the
+// style of C and Java, such as " * text", or " *\tcode" for lines of code.
+var starPrefix = regexp.MustCompile(`^[ \t]*\*(?:[ \t]|$)`)
+
+// checkCommentStyle reports the doc comment of a declaration described by what if it
+// contains block comments, if -line-comments is set, since Go uses line comments for
+// the documentation of declarations. The diagnostic carries a fix converting the block
+// comments to line comments when every one of them is on lines of its own.
+func (l *Linter) checkCommentStyle(pass *analysis.Pass, what string, pos token.Pos, doc *ast.CommentGroup) {
+	if !l.requireLineComments {
+		return
+	}
+
+	var edits []analysis.TextEdit
+	fixable := true
+	for _, c := range doc.List {
+		if !strings.HasPrefix(c.Text, "/*") {
+			continue
+		}
+
+		edit, ok := lineCommentEdit(pass, c)
+		if !ok {
+			fixable = false
+		}
+		edits = append(edits, edit)
+	}
+
+	if len(edits) == 0 {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     pos,
+		Message: "comment for " + what + " should use line comments (//) instead of block comments (/* */)",
+	}
+
+	if fixable {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Convert to line comments",
+			TextEdits: edits,
+		}}
+	}
+
+	l.reportDiagnostic(pass, RuleLineComment, diag)
+}
+
+// lineCommentEdit returns the edit converting the block comment c to line comments. It
+// returns false if c shares its lines with code, which the conversion would comment
+// out.
+func lineCommentEdit(pass *analysis.Pass, c *ast.Comment) (analysis.TextEdit, bool) {
+	tf := pass.Fset.File(c.Pos())
+
+	src, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return analysis.TextEdit{}, false
+	}
+
+	start, end := tf.Offset(c.Pos()), tf.Offset(c.End())
+	lineStart := strings.LastIndexByte(string(src[:start]), '\n') + 1
+	prefix := string(src[lineStart:start])
+
+	rest := string(src[end:])
+	if i := strings.IndexByte(rest, '\n'); i >= 0 {
+		rest = rest[:i]
+	}
+
+	if strings.TrimSpace(prefix) != "" || strings.TrimSpace(rest) != "" {
+		return analysis.TextEdit{}, false
+	}
+
+	lines := blockCommentLines(c.Text)
+	for i, line := range lines {
+		switch {
+		case line == "":
+			line = "//"
+		case strings.HasPrefix(line, "\t"):
+			line = "//" + line
+		default:
+			line = "// " + line
+		}
+
+		if i > 0 {
+			line = prefix + line
+		}
+		lines[i] = line
+	}
+
+	return analysis.TextEdit{
+		Pos:     c.Pos(),
+		End:     c.End(),
+		NewText: []byte(strings.Join(lines, "\n")),
+	}, true
+}
+
+// blockCommentLines returns the lines of text of the block comment, including its
+// comment markers, without leading and trailing blank lines, asterisks leading every
+// line, and the indentation common to the lines.
+func blockCommentLines(comment string) []string {
+	lines := strings.Split(comment[len("/*"):len(comment)-len("*/")], "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t\r")
+	}
+
+	// The text on the line of the opening marker is separated from it by a space.
+	lines[0] = strings.TrimPrefix(lines[0], " ")
+	first := lines[0] != ""
+
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+		first = false
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return []string{""}
+	}
+
+	rest := lines
+	if first {
+		rest = lines[1:]
+	}
+
+	starred := len(rest) > 0
+	for _, line := range rest {
+		if !starPrefix.MatchString(line) {
+			starred = false
+		}
+	}
+
+	if starred {
+		// The space following an asterisk separates it from the text, while a tab
+		// indents a line of code.
+		for i := range rest {
+			rest[i] = strings.TrimPrefix(strings.TrimLeft(rest[i], " \t")[len("*"):], " ")
+		}
+	} else {
+		common := -1
+		for _, line := range rest {
+			if line != "" && (common < 0 || indentation(line) < common) {
+				common = indentation(line)
+			}
+		}
+
+		for i := range rest {
+			if rest[i] != "" {
+				rest[i] = rest[i][common:]
+			}
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,192 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Scopes of the issues a suppression applies to.
+const (
+	// ScopeFile is the scope of a suppression covering every issue in a file.
+	ScopeFile = "file"
+
+	// ScopePackage is the scope of a suppression covering every issue in a package,
+	// including those reported for the package as a whole.
+	ScopePackage = "package"
+)
+
+// nolintPattern matches golangci-lint style //nolint comments, capturing the optional
+// comma separated list of linters they apply to.
+var nolintPattern = regexp.MustCompile(`^//nolint(?::([\w-]+(?:,[\w-]+)*))?(?:\s|$)`)
+
+// Result is the result of the doculint analyzer for a package.
+type Result struct {
+	// Suppressions are the suppressions found in the files of the package.
+	Suppressions []*Suppression
+}
+
+// Suppression is a //nolint comment, placed after the package clause of a file, that
+// suppresses the issues of the file or, in the file holding the package comment or in
+// doc.go, of the whole package.
+type Suppression struct {
+	// Pos is the position of the comment.
+	Pos token.Pos
+
+	// Scope is the scope of the issues suppressed, ScopeFile or ScopePackage.
+	Scope string
+
+	// Suppressed is the number of issues that were suppressed.
+	Suppressed int
+
+	// file is the file the comment is in.
+	file *token.File
+}
+
+// covers reports whether s suppresses an issue reported by rule at pos. Issues reported
+// for the package as a whole are only covered by package suppressions, even though they
+// are positioned in a file.
+func (s *Suppression) covers(fset *token.FileSet, rule Rule, pos token.Pos) bool {
+	if s.Scope == ScopePackage {
+		return true
+	}
+
+	return !rule.Package && pos.IsValid() && fset.File(pos) == s.file
+}
+
+// findSuppressions returns the suppressions in the files of the package analyzed by
+// pass, where filename is the name of the file holding the package comment. File
+// suppressions are returned before package suppressions, so that issues are counted
+// against the most specific suppression covering them.
+func findSuppressions(pass *analysis.Pass, filename string) []*Suppression {
+	var files, pkgs []*Suppression
+	for _, file := range pass.Files {
+		tf := pass.Fset.File(file.Package)
+		name := filepath.Base(tf.Name())
+
+		for _, pos := range fileSuppressions(file) {
+			if name == filename || name == "doc.go" {
+				pkgs = append(pkgs, &Suppression{Pos: pos, Scope: ScopePackage, file: tf})
+			} else {
+				files = append(files, &Suppression{Pos: pos, Scope: ScopeFile, file: tf})
+			}
+		}
+	}
+
+	return append(files, pkgs...)
+}
+
+// fileSuppressions returns the positions of the //nolint comments applying to
+// doculint in file that are placed after its package clause, either on the same line
+// or before the first declaration other than imports, and are not the doc comment of a
+// declaration.
+func fileSuppressions(file *ast.File) []token.Pos {
+	end := file.End()
+	for _, decl := range file.Decls {
+		if gd, ok := decl.(*ast.GenDecl); !ok || gd.Tok != token.IMPORT {
+			end = decl.Pos()
+			break
+		}
+	}
+
+	docs := make(map[*ast.CommentGroup]bool)
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			docs[decl.Doc] = true
+		case *ast.GenDecl:
+			docs[decl.Doc] = true
+		}
+	}
+
+	var positions []token.Pos
+	for _, cg := range file.Comments {
+		if cg.Pos() < file.Package || cg.Pos() >= end || docs[cg] {
+			continue
+		}
+
+		for _, c := range cg.List {
+			if appliesToDoculint(c.Text) {
+				positions = append(positions, c.Pos())
+			}
+		}
+	}
+
+	return positions
+}
+
+// appliesToDoculint reports whether the comment, including its comment markers, is a
+// //nolint comment that applies to doculint, which is the case if it lists no linters
+// or lists either "all" or "doculint".
+func appliesToDoculint(comment string) bool {
+	m := nolintPattern.FindStringSubmatch(comment)
+	if m == nil {
+		return false
+	}
+
+	if m[1] == "" {
+		return true
+	}
+
+	for _, linter := range strings.Split(m[1], ",") {
+		if linter == "all" || linter == "doculint" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkNolintReasons reports the //nolint comments in the files of the package analyzed
+// by pass that do not explain why issues are suppressed, when -nolint-reasons is set.
+// The explanation follows the directive as another comment, as in
+// "//nolint:doculint // Generated by protoc.", so that suppressions remain auditable.
+// Every //nolint comment is checked, whichever linters it applies to.
+func (l *Linter) checkNolintReasons(pass *analysis.Pass) {
+	if !l.requireNolintReasons {
+		return
+	}
+
+	for _, file := range pass.Files {
+		for _, cg := range file.Comments {
+			for _, c := range cg.List {
+				m := nolintPattern.FindString(c.Text)
+				if m == "" {
+					continue
+				}
+
+				reason := strings.TrimSpace(c.Text[len(m):])
+				if strings.TrimSpace(strings.TrimPrefix(reason, "//")) == "" {
+					l.report(pass, RuleNolintReason, c.Pos(), "%s comment should explain why issues are suppressed, as in \"%s // reason\"", strings.TrimSpace(m), strings.TrimSpace(m))
+				}
+			}
+		}
+	}
+}
+
+// suppress returns a copy of pass whose Report function drops the diagnostics covered
+// by suppressions, counting them against the first suppression covering them.
+func (l *Linter) suppress(pass *analysis.Pass, suppressions []*Suppression) *analysis.Pass {
+	if len(suppressions) == 0 {
+		return pass
+	}
+
+	p := *pass
+	p.Report = func(diag analysis.Diagnostic) {
+		rule, _ := l.LookupRule(diag.Category)
+		for _, s := range suppressions {
+			if s.covers(pass.Fset, rule, diag.Pos) {
+				s.Suppressed++
+				return
+			}
+		}
+
+		pass.Report(diag)
+	}
+
+	return &p
+}
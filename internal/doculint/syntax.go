@@ -0,0 +1,276 @@
+package doculint
+
+import (
+	"go/ast"
+	"go/doc/comment"
+	"go/token"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkDocSyntax parses the doc comment of a declaration described by what with
+// go/doc/comment, as pkg.go.dev does, when -doc-syntax is set, and reports the
+// constructs that will not render as intended: lines that will render as headings
+// without being marked as such with "#", doc links missing their closing bracket, and
+// list items and code that are not indented, which render as part of a paragraph.
+// Comments free of these that are not in the canonical format gofmt would give them
+// are reported with a fix reformatting them.
+func (l *Linter) checkDocSyntax(pass *analysis.Pass, what string, pos token.Pos, doc *ast.CommentGroup) {
+	if !l.checkSyntax {
+		return
+	}
+
+	text := doc.Text()
+	parsed := new(comment.Parser).Parse(text)
+
+	found := false
+	for _, block := range parsed.Content {
+		if heading, ok := block.(*comment.Heading); ok && !hasLine(text, "# "+plainText(heading.Text)) {
+			l.report(pass, RuleDocSyntax, pos, "comment for %s has a line that will render as a heading, \"%s\", mark it with # or punctuate it", what, plainText(heading.Text))
+			found = true
+		}
+	}
+
+	for _, block := range parsed.Content {
+		paragraph, ok := block.(*comment.Paragraph)
+		if !ok {
+			continue
+		}
+
+		// Only the first list item and line of code of each paragraph are reported.
+		list, code := false, false
+		for _, line := range strings.Split(plainText(paragraph.Text), "\n") {
+			switch {
+			case !list && listMarkerPattern.MatchString(line):
+				l.report(pass, RuleDocBlock, pos, "comment for %s has a list item \"%s\" that is not indented, it will render as part of a paragraph", what, line)
+				list, found = true, true
+			case !code && looksLikeCode(line):
+				l.report(pass, RuleDocBlock, pos, "comment for %s has code \"%s\" that is not indented, it will render as part of a paragraph", what, line)
+				code, found = true, true
+			}
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if indentation(line) > 0 {
+			// Code blocks and lists render as written.
+			continue
+		}
+
+		if link := unclosedLink(line); link != "" {
+			l.report(pass, RuleDocSyntax, pos, "comment for %s has an unclosed doc link \"%s\", which will render as literal text", what, link)
+			found = true
+		}
+	}
+
+	if found {
+		return
+	}
+
+	edit, ok := canonicalDocEdit(pass, doc, parsed)
+	if !ok {
+		return
+	}
+
+	l.reportDiagnostic(pass, RuleDocSyntax, analysis.Diagnostic{
+		Pos:     pos,
+		Message: "comment for " + what + " is not in the canonical doc comment format",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Reformat the comment as gofmt would",
+			TextEdits: []analysis.TextEdit{edit},
+		}},
+	})
+}
+
+// canonicalDocEdit returns the edit replacing the line comments of doc with the canonical
+// formatting of parsed, the parsed text of doc, as gofmt formats doc comments. It reports
+// false if doc is already formatted canonically, if it is not the comment of a package
+// clause or top-level declaration, the only ones gofmt formats, or if it contains block
+// comments or directives other than at its end, which gofmt handles differently.
+func canonicalDocEdit(pass *analysis.Pass, doc *ast.CommentGroup, parsed *comment.Doc) (analysis.TextEdit, bool) {
+	if !isTopLevelDoc(pass, doc) {
+		return analysis.TextEdit{}, false
+	}
+
+	var lines []*ast.Comment
+	for i, c := range doc.List {
+		if !strings.HasPrefix(c.Text, "//") {
+			return analysis.TextEdit{}, false
+		}
+
+		if isDirective(c.Text) {
+			for _, rest := range doc.List[i:] {
+				if !isDirective(rest.Text) {
+					return analysis.TextEdit{}, false
+				}
+			}
+			break
+		}
+
+		lines = append(lines, c)
+	}
+
+	// The blank line gofmt places between the text and the directives is not part of
+	// the text.
+	for len(lines) > 0 && lines[len(lines)-1].Text == "//" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return analysis.TextEdit{}, false
+	}
+
+	var canonical []string
+	for _, line := range strings.Split(strings.TrimSuffix(string(new(comment.Printer).Comment(parsed)), "\n"), "\n") {
+		switch {
+		case line == "":
+			canonical = append(canonical, "//")
+		case strings.HasPrefix(line, "\t"):
+			canonical = append(canonical, "//"+line)
+		default:
+			canonical = append(canonical, "// "+line)
+		}
+	}
+
+	var current []string
+	for _, c := range lines {
+		current = append(current, c.Text)
+	}
+
+	if strings.Join(current, "\n") == strings.Join(canonical, "\n") {
+		return analysis.TextEdit{}, false
+	}
+
+	// Keep the indentation of the first line for the following ones.
+	tf := pass.Fset.File(lines[0].Pos())
+	src, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return analysis.TextEdit{}, false
+	}
+	prefix := src[tf.Offset(tf.LineStart(tf.Line(lines[0].Pos()))):tf.Offset(lines[0].Pos())]
+
+	return analysis.TextEdit{
+		Pos:     lines[0].Pos(),
+		End:     lines[len(lines)-1].End(),
+		NewText: []byte(strings.Join(canonical, "\n"+string(prefix))),
+	}, true
+}
+
+// isTopLevelDoc reports whether doc is the comment of the package clause or of a
+// top-level declaration of one of the files analyzed by pass.
+func isTopLevelDoc(pass *analysis.Pass, doc *ast.CommentGroup) bool {
+	for _, file := range pass.Files {
+		if doc.Pos() < file.FileStart || doc.Pos() > file.FileEnd {
+			continue
+		}
+
+		if file.Doc == doc {
+			return true
+		}
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Doc == doc {
+					return true
+				}
+			case *ast.GenDecl:
+				if decl.Doc == doc {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// listMarkerPattern matches the lines of doc comment paragraphs beginning with a list
+// marker, such as "- item" or "1. item", which only start lists when indented.
+var listMarkerPattern = regexp.MustCompile(`^(?:[-*+•]|\d+[.)])\s+\S`)
+
+// codeLinePatterns match the lines of doc comment paragraphs that look like Go code
+// rather than prose: lines opening or closing a block, assignments, and single call
+// expressions, such as "fmt.Println(x)".
+var codeLinePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\{$`),
+	regexp.MustCompile(`^\}`),
+	regexp.MustCompile(`^\w+(?:, *\w+)* :?= \S`),
+	regexp.MustCompile(`^[A-Za-z_][\w.]*\([^()]*\);?$`),
+}
+
+// looksLikeCode reports whether the given line of a doc comment paragraph looks like Go
+// code rather than prose.
+func looksLikeCode(line string) bool {
+	line = strings.TrimSpace(line)
+
+	for _, pattern := range codeLinePatterns {
+		if pattern.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasLine reports whether text contains line as one of its lines.
+func hasLine(text, line string) bool {
+	for _, l := range strings.Split(text, "\n") {
+		if l == line {
+			return true
+		}
+	}
+
+	return false
+}
+
+// plainText returns the text of the given doc comment text elements without formatting.
+func plainText(text []comment.Text) string {
+	var b strings.Builder
+	for _, t := range text {
+		switch t := t.(type) {
+		case comment.Plain:
+			b.WriteString(string(t))
+		case comment.Italic:
+			b.WriteString(string(t))
+		case *comment.Link:
+			b.WriteString(plainText(t.Text))
+		case *comment.DocLink:
+			b.WriteString(plainText(t.Text))
+		}
+	}
+
+	return b.String()
+}
+
+// unclosedLink returns the first word of line that opens a doc link without closing
+// it, being a bracket not preceded by a word and followed by an identifier that runs to whitespace or the end of
+// the line, as in "see [Foo", or an empty string if there is none. Doc links cannot
+// span lines, and other punctuation following the identifier is taken as prose, such as
+// the interval "[start, end)".
+func unclosedLink(line string) string {
+	for offset := 0; ; {
+		i := strings.IndexByte(line[offset:], '[')
+		if i < 0 {
+			return ""
+		}
+		start := offset + i
+
+		end := start + 1
+		for end < len(line) && (line[end] == '.' || line[end] == '*' || !isNotWordRune(rune(line[end]))) {
+			end++
+		}
+
+		before, _ := utf8.DecodeLastRuneInString(line[:start])
+		r, _ := utf8.DecodeRuneInString(line[start+1:])
+		if (start == 0 || isNotWordRune(before)) && unicode.IsLetter(r) && (end == len(line) || line[end] == ' ' || line[end] == '\t') && !strings.Contains(line[end:], "]") {
+			return line[start:end]
+		}
+
+		offset = start + 1
+	}
+}
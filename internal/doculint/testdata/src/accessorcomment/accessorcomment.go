@@ -0,0 +1,19 @@
+// Package accessorcomment holds the testdata of the accessor-comment rule.
+package accessorcomment
+
+import "time"
+
+// Client sends requests to a server.
+type Client struct{}
+
+// Timeout returns the timeout.
+func (c *Client) Timeout() time.Duration { return 0 } // want `comment for method "Timeout" only echoes its name, describe what Timeout of Client means and how it is used`
+
+// SetTimeout sets the timeout.
+func (c *Client) SetTimeout(d time.Duration) {} // want `comment for method "SetTimeout" only echoes its name, describe what Timeout of Client means and how it is used`
+
+// Retries returns how many times requests of the Client are retried, see [Client.SetRetries].
+func (c *Client) Retries() int { return 0 }
+
+// SetRetries sets how many times requests of the Client are retried, see [Client.Retries].
+func (c *Client) SetRetries(n int) {}
@@ -0,0 +1,11 @@
+// Package bannedphrase holds the testdata of the banned-phrase rule.
+package bannedphrase
+
+// Render simply returns the HTML of the page.
+func Render() string { return "" } // want `comment for function "Render" contains "simply", describe what it does instead`
+
+// Paint draws the page. This function is safe for concurrent use.
+func Paint() {} // want `comment for function "Paint" contains "this function", describe what it does instead`
+
+// Draw draws the page. It is safe for concurrent use.
+func Draw() {}
@@ -0,0 +1,24 @@
+// Package blockgrouping holds the testdata of the block-grouping rule.
+package blockgrouping
+
+// Colors of a widget.
+const ( // want `constant block has 3 entries and should be split into groups separated by blank lines, each introduced by a comment`
+	// Red is the color of errors.
+	Red = "red"
+	// Green is the color of successes.
+	Green = "green"
+	// Blue is the color of links.
+	Blue = "blue"
+)
+
+// Sizes of a widget.
+const (
+	// Small is the smallest size.
+	Small = 1
+
+	// Medium is the default size.
+	Medium = 2
+
+	// Large is the largest size.
+	Large = 3
+)
@@ -0,0 +1,6 @@
+// Copyright 2024 The Doculint Authors. // want +2 `file with build constraint "!windows" should have a comment explaining why the constraint exists`
+
+//go:build !windows
+
+// Package buildconstraintcomment holds the testdata of the build-constraint-comment rule.
+package buildconstraintcomment
@@ -0,0 +1,5 @@
+//go:build !windows
+
+// Rendering to a terminal is only supported outside of Windows.
+
+package buildconstraintcomment
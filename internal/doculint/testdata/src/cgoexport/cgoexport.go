@@ -0,0 +1,14 @@
+// Package cgoexport holds the testdata of the cgo-export rule.
+package cgoexport // want +2 `//export directive of function "Render" should come after its comment`
+
+//export Render
+// Render returns the HTML of the page.
+func Render() {}
+
+//export Paint
+func Paint() {} // want `function "Paint" is exported to C and has no comment associated with it above its //export directive`
+
+// Draw draws the page.
+//
+//export Draw
+func Draw() {}
@@ -0,0 +1,10 @@
+// Package commentmarker holds the testdata of the comment-marker rule.
+package commentmarker
+
+// Render returns the HTML of the page. TODO: escape attributes.
+func Render() string { return "" } // want `comment for function "Render" contains "TODO", which will be published in its documentation`
+
+// Paint draws the page.
+func Paint() {
+	// TODO: draw the borders.
+}
@@ -0,0 +1,13 @@
+// Package commentperiod holds the testdata of the comment-period rule.
+package commentperiod
+
+// Render returns the HTML of the page
+func Render() string { return "" } // want `comment for function "Render" should end with a period`
+
+// Paint draws the page.
+func Paint() {}
+
+// Draw draws the page, as in:
+//
+//	Draw()
+func Draw() {}
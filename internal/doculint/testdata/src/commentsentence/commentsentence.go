@@ -0,0 +1,14 @@
+// Package commentsentence holds the testdata of the comment-sentence rule.
+package commentsentence
+
+// render
+func render() string { return "" } // want `comment for function "render" should be a complete sentence`
+
+// paint draws the page.
+func paint() {}
+
+// Widget helper type.
+type Widget struct{} // want `comment for type "Widget" should be a complete sentence`
+
+// Page is a tree of widgets.
+type Page struct{}
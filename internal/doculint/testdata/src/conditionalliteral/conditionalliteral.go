@@ -0,0 +1,24 @@
+// Package conditionalliteral holds the testdata of the conditional-literal rule.
+package conditionalliteral
+
+// maxRetries is the number of times a request is retried.
+const maxRetries = 3
+
+// retry reports whether a request failed retries times should be retried.
+func retry(retries int) bool {
+	if retries > 3 { // want `literal found in conditional`
+		return false
+	}
+
+	return retries < maxRetries
+}
+
+// command returns the number of arguments taken by the command named name.
+func command(name string) int {
+	switch name {
+	case "serve":
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,13 @@
+// Package configfield holds the testdata of the config-field rule.
+package configfield
+
+import "time"
+
+// ClientOptions configures a client.
+type ClientOptions struct { // want +1 `comment for field "Timeout" of configuration type "ClientOptions" should document its default value`
+	// Timeout is the request timeout.
+	Timeout time.Duration
+
+	// Retries is the number of times a request is retried, by default 3.
+	Retries int
+}
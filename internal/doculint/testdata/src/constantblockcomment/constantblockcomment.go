@@ -0,0 +1,16 @@
+// Package constantblockcomment holds the testdata of the constant-block-comment rule.
+package constantblockcomment
+
+const ( // want `constant block has no comment associated with it`
+	// Small is the smallest size.
+	Small = 1
+
+	// Large is the largest size.
+	Large = 2
+)
+
+// Colors of a widget.
+const (
+	// Red is the color of errors.
+	Red = "red"
+)
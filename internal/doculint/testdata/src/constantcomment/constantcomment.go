@@ -0,0 +1,18 @@
+// Package constantcomment holds the testdata of the constant-comment rule.
+package constantcomment
+
+// MaxSize and MinSize bound the size of a widget.
+const MaxSize, MinSize = 10, 1 // want `constants "MaxSize, MinSize" should be separated and each have a comment associated with them`
+
+const Width = 3 // want `constant "Width" has no comment associated with it`
+
+// height is the height of a widget.
+const Height = 4 // want `comment for constant "Height" should begin with "Height"`
+
+// Sizes of a widget.
+const (
+	// Small is the smallest size.
+	Small = 1
+
+	Large = 2 // want `constant "Large" has no comment associated with it`
+)
@@ -0,0 +1,11 @@
+// Package constructorcomment holds the testdata of the constructor-comment rule.
+package constructorcomment
+
+// Client sends requests to a server.
+type Client struct{}
+
+// NewClient creates a new instance.
+func NewClient() *Client { return nil } // want `comment for constructor "NewClient" should mention the type "Client" it returns, as in "NewClient returns a Client ..."`
+
+// NewDefaultClient returns a Client using the default transport.
+func NewDefaultClient() *Client { return nil }
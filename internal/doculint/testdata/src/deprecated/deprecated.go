@@ -0,0 +1,18 @@
+// Package deprecated holds the testdata of the deprecated rule.
+package deprecated
+
+// Render returns the HTML of the page. Deprecated: use RenderTo.
+func Render() string { return "" } // want `deprecation notice in comment for function "Render" should be in its own paragraph`
+
+// Paint draws the page.
+//
+// deprecated: use Draw instead.
+func Paint() {} // want `deprecation notice in comment for function "Paint" should begin with "Deprecated: "`
+
+// Draw draws the page.
+//
+// Deprecated: Use RenderTo instead.
+func Draw() {}
+
+// RenderTo writes the HTML of the page.
+func RenderTo() {}
@@ -0,0 +1,9 @@
+// Package detachedcomment holds the testdata of the detached-comment rule.
+package detachedcomment
+
+// Render returns the HTML of the page.
+
+func Render() string { return "" } // want `comment for function "Render" is separated from it by a blank line, so it is not associated with it` `function "Render" has no comment associated with it`
+
+// Paint draws the page.
+func Paint() {}
@@ -0,0 +1,11 @@
+// Package docblock holds the testdata of the doc-block rule.
+package docblock
+
+// Render returns the HTML of the page, as in:
+// html := Render()
+func Render() string { return "" } // want `comment for function "Render" has code "html := Render\(\)" that is not indented, it will render as part of a paragraph`
+
+// Paint draws the page, as in:
+//
+//	Paint()
+func Paint() {}
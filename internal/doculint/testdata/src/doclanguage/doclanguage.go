@@ -0,0 +1,8 @@
+// Package doclanguage holds the testdata of the doc-language rule.
+package doclanguage
+
+// Render gibt das HTML der Seite zurück, wenn die Seite geladen wurde.
+func Render() string { return "" } // want `comment for function "Render" appears to be written in German rather than English`
+
+// Paint draws the page on the screen when it has been loaded.
+func Paint() {}
@@ -0,0 +1,8 @@
+// Package doclink holds the testdata of the doc-link rule.
+package doclink
+
+// Render returns the HTML of the page, see [RenderAll].
+func Render() string { return "" } // want `doc link \[RenderAll\] in comment for function "Render" does not refer to a declared identifier`
+
+// Paint draws the page, see [Render] and [strings.Builder].
+func Paint() {}
@@ -0,0 +1,12 @@
+// Package docsyntax holds the testdata of the doc-syntax rule.
+package docsyntax
+
+// Render returns the HTML of the page, see [Paint.
+func Render() string { return "" } // want `comment for function "Render" has an unclosed doc link "\[Paint.", which will render as literal text`
+
+// Paint draws the page.
+//
+// Borders
+//
+// The borders of the page are drawn last.
+func Paint() {} // want `comment for function "Paint" has a line that will render as a heading, "Borders", mark it with # or punctuate it`
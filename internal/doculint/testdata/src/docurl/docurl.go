@@ -0,0 +1,8 @@
+// Package docurl holds the testdata of the doc-url rule.
+package docurl // want +2 `URL "https://example" in comment for function "Render" has host "example" without a top-level domain, it may be truncated`
+
+// Render returns the HTML of the page, see https://example for the format.
+func Render() string { return "" }
+
+// Paint draws the page, see https://example.com/format for the format.
+func Paint() {}
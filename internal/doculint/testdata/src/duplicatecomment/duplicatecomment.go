@@ -0,0 +1,11 @@
+// Package duplicatecomment holds the testdata of the duplicate-comment rule.
+package duplicatecomment
+
+// Close closes the connection to the server.
+func Close() {}
+
+// Flush closes the connection to the server.
+func Flush() {} // want `comment for function "Flush" duplicates the comment for function "Close" in "duplicatecomment.go" at line 5, it is likely a stale copy`
+
+// Open opens the connection to the server.
+func Open() {}
@@ -0,0 +1,2 @@
+// Package duplicatepackagecomment holds the testdata of the duplicate-package-comment rule.
+package duplicatepackagecomment
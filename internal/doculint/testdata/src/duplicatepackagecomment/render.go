@@ -0,0 +1,2 @@
+// Package duplicatepackagecomment renders widgets.
+package duplicatepackagecomment // want `package "duplicatepackagecomment" already has a comment in "duplicatepackagecomment.go"`
@@ -0,0 +1,15 @@
+// Package embeddedcomment holds the testdata of the embedded-comment rule.
+package embeddedcomment
+
+import "sync"
+
+// Client sends requests to a server.
+type Client struct { // want +1 `field "sync.Mutex" embedded in type "Client" should have a comment explaining the behavior it promotes`
+	sync.Mutex
+}
+
+// Server answers the requests of clients.
+type Server struct {
+	// RWMutex guards the connections of the server.
+	sync.RWMutex
+}
@@ -0,0 +1,8 @@
+// Package errorcomment holds the testdata of the error-comment rule.
+package errorcomment
+
+// Load reads the page at path.
+func Load(path string) (string, error) { return "", nil } // want `comment for function "Load" should document when it returns an error`
+
+// Save writes the page to path, returning an error if it cannot be created.
+func Save(path string) error { return nil }
@@ -0,0 +1,13 @@
+// Package errorsentinel holds the testdata of the error-sentinel rule.
+package errorsentinel
+
+import "errors"
+
+// ErrNotFound is an error.
+var ErrNotFound = errors.New("not found") // want `comment for error "ErrNotFound" should be of the form "ErrNotFound is returned when ..."`
+
+// ErrClosed is returned when the page is closed.
+var ErrClosed = errors.New("closed")
+
+// ErrTimeout is returned when the page takes too long to render.
+var ErrTimeout = "timeout" // want `variable "ErrTimeout" is named like an error sentinel but is of type string, which is not an error`
@@ -0,0 +1,8 @@
+// Package example holds the testdata of the example rule.
+package example
+
+// Render returns the HTML of the page.
+func Render() string { return "" }
+
+// Paint draws the page.
+func Paint() {} // want `exported function "Paint" has no example, add an ExamplePaint function to the tests of package "example"`
@@ -0,0 +1,12 @@
+package example_test
+
+import (
+	"fmt"
+
+	"example"
+)
+
+func ExampleRender() {
+	fmt.Println(example.Render())
+	// Output:
+}
@@ -0,0 +1,52 @@
+// Package exitcomment holds the testdata of the exit-comment rule.
+package exitcomment
+
+import (
+	"io"
+	"os"
+)
+
+// Must returns v.
+func Must(v int, err error) int { // want `comment for function "Must" should document that it terminates the process through os.Exit`
+	if err != nil {
+		os.Exit(1)
+	}
+
+	return v
+}
+
+// MustParse returns v, and exits the process if err is not nil.
+func MustParse(v int, err error) int {
+	if err != nil {
+		os.Exit(1)
+	}
+
+	return v
+}
+
+// MustLoad returns v, and calls os.Exit if err is not nil.
+func MustLoad(v int, err error) int {
+	if err != nil {
+		os.Exit(1)
+	}
+
+	return v
+}
+
+// MustRead returns v, or terminates the process if err is not nil.
+func MustRead(v int, err error) int {
+	if err != nil {
+		os.Exit(1)
+	}
+
+	return v
+}
+
+// MustOpen returns v, and treats io.EOF as a nonfatal error.
+func MustOpen(v int, err error) int { // want `comment for function "MustOpen" should document that it terminates the process through os.Exit`
+	if err != nil && err != io.EOF {
+		os.Exit(1)
+	}
+
+	return v
+}
@@ -0,0 +1,4 @@
+// Copyright The Doculint Authors.
+
+// Package fileheader holds the testdata of the file-header rule.
+package fileheader
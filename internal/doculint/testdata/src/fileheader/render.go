@@ -0,0 +1,4 @@
+package fileheader // want `file should begin with the header in "testdata/src/fileheader/header.txt"`
+
+// Render returns the HTML of the page.
+func Render() string { return "" }
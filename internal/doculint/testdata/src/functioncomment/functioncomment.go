@@ -0,0 +1,15 @@
+// Package functioncomment holds the testdata of the function-comment rule.
+package functioncomment
+
+// Draws the widget.
+func Render() {} // want `comment for function "Render" should begin with "Render"`
+
+// paint draws the widget.
+func Paint() {} // want `comment for function "Paint" should begin with "Paint"`
+
+func Erase() {} // want `function "Erase" has no comment associated with it`
+
+// Layout positions the widget.
+func Layout() {}
+
+func main() {} // want `function "main" has no comment associated with it`
@@ -0,0 +1,8 @@
+// Package functionverb holds the testdata of the function-verb rule.
+package functionverb
+
+// Render the page.
+func Render() {} // want `comment for function "Render" should continue with a verb, as in "Render returns", rather than "the"`
+
+// Paint draws the page.
+func Paint() {}
@@ -0,0 +1,13 @@
+// Package generatecomment holds the testdata of the generate-comment rule.
+package generatecomment // want +2 `//go:generate directive should be preceded by a comment explaining what it generates and how to regenerate it`
+
+//go:generate stringer -type=Size
+
+// The String method of Color is generated, run go generate after adding colors.
+//go:generate stringer -type=Color
+
+// Size is the size of a widget.
+type Size int
+
+// Color is the color of a widget.
+type Color int
@@ -0,0 +1,8 @@
+// Package glossary holds the testdata of the glossary rule.
+package glossary // want +2 `comment for function "Allowed" uses "whitelist", use the preferred term "allowlist" instead`
+
+// Allowed reports whether host is on the whitelist.
+func Allowed(host string) bool { return false }
+
+// Denied reports whether host is on the denylist.
+func Denied(host string) bool { return false }
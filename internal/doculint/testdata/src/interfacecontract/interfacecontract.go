@@ -0,0 +1,14 @@
+// Package interfacecontract holds the testdata of the interface-contract rule.
+package interfacecontract
+
+// Store stores pages.
+type Store interface { // want `comment for interface "Store" has 1 sentences but should have at least 2 describing the contract of its implementations`
+	// Load returns the page at path.
+	Load(path string) string
+}
+
+// Cache caches pages. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the page at path.
+	Get(path string) string
+}
@@ -0,0 +1,22 @@
+// Package iotaenum holds the testdata of the iota-enum rule.
+package iotaenum
+
+// Size is the size of a widget.
+type Size int
+
+// Constants.
+const ( // want `comment for enum block should describe the enum and mention its type "Size"`
+	// Small is the smallest size.
+	Small Size = iota
+	Large
+)
+
+// Colors of a widget, as a Color.
+const (
+	// Red is the color of errors.
+	Red Color = iota
+	Green
+)
+
+// Color is the color of a widget.
+type Color int
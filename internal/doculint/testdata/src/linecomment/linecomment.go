@@ -0,0 +1,15 @@
+// Package linecomment holds the testdata of the line-comment rule.
+package linecomment
+
+/* Render returns the HTML of the page. */
+func Render() string { return "" } // want `comment for function "Render" should use line comments \(//\) instead of block comments \(/\* \*/\)`
+
+/*
+ * Paint draws the page, as in:
+ *
+ *	Paint()
+ */
+func Paint() {} // want `comment for function "Paint" should use line comments` `comment for function "Paint" has a list item "\* Paint draws the page, as in:" that is not indented` `comment for function "Paint" should begin with "Paint"`
+
+// Draw draws the page.
+func Draw() {}
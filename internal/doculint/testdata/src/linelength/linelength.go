@@ -0,0 +1,12 @@
+// Package linelength holds the testdata of the line-length rule.
+package linelength // want +2 `comment line is 119 characters long, which exceeds the limit of 80`
+
+// Render returns the HTML of the page, escaping its attributes and text so that it can be embedded in any page safely.
+func Render() string { return "" }
+
+// Paint draws the page, as in:
+//
+//	Paint() // Draws the page on the screen, which is the only surface supported by the package.
+//
+// See https://pkg.go.dev/golang.org/x/tools/go/analysis/analysistest#RunWithSuggestedFixes for the tests.
+func Paint() {}
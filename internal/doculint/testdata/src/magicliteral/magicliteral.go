@@ -0,0 +1,18 @@
+// Package magicliteral holds the testdata of the magic-literal rule.
+package magicliteral
+
+import "time"
+
+// retryDelay is the time waited before retrying a request.
+const retryDelay = 300 * time.Millisecond
+
+// Retry waits before retrying a request.
+func Retry() {
+	time.Sleep(300) // want `numeric literal 300 passed to time.Sleep, use a named constant`
+	time.Sleep(retryDelay)
+}
+
+// Width returns the width of the page.
+func Width() int {
+	return 640 // want `numeric literal 640 returned by Width, use a named constant`
+}
@@ -0,0 +1,11 @@
+// Package methodreceiver holds the testdata of the method-receiver rule.
+package methodreceiver
+
+// Client sends requests to a server.
+type Client struct{}
+
+// Close releases the resources.
+func (c *Client) Close() {} // want `comment for method "Close" should mention its receiver type "Client" in the first sentence`
+
+// Flush writes the buffered requests of the Client.
+func (c *Client) Flush() {}
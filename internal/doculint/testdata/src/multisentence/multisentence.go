@@ -0,0 +1,8 @@
+// Package multisentence holds the testdata of the multi-sentence rule.
+package multisentence
+
+// Render renders.
+func Render(page string, theme string, width, height int) {} // want `comment for function "Render", which has 4 parameters, should have at least 2 sentences`
+
+// Paint draws page. The page is laid out within width and height using theme.
+func Paint(page string, theme string, width, height int) {}
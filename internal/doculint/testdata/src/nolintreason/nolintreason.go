@@ -0,0 +1,2 @@
+// Package nolintreason holds the testdata of the nolint-reason rule.
+package nolintreason
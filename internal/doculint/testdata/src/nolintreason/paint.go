@@ -0,0 +1,3 @@
+package nolintreason //nolint:doculint // Generated by protoc.
+
+func Paint() {}
@@ -0,0 +1,5 @@
+package nolintreason // want +2 `//nolint:doculint comment should explain why issues are suppressed, as in "//nolint:doculint // reason"`
+
+//nolint:doculint
+
+func Render() {}
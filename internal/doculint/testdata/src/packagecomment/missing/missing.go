@@ -0,0 +1 @@
+package missing // want `package "missing" has no comment associated with it in "missing.go"`
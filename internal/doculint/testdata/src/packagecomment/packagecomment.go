@@ -0,0 +1,2 @@
+// Holds the testdata of the package-comment rule.
+package packagecomment // want `comment for package "packagecomment" should begin with "Package packagecomment"`
@@ -0,0 +1,2 @@
+// Package packagecommentlength has tests.
+package packagecommentlength // want `comment for package "packagecommentlength" has 4 words but should have at least 10`
@@ -0,0 +1,2 @@
+// Package packagefile holds the testdata of the package-file rule.
+package packagefile // want `package "packagefile" has no file "packagefile.go" containing package comment, move the comment found in "doc.go" to it`
@@ -0,0 +1,2 @@
+// Package string_utils holds the testdata of the package-name rule.
+package string_utils // want `package "string_utils" should not contain - or _ in name`
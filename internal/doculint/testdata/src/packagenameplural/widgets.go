@@ -0,0 +1,2 @@
+// Package widgets holds the testdata of the package-name-plural rule.
+package widgets // want `package "widgets" should have a singular name, such as "widget"`
@@ -0,0 +1,2 @@
+// Package util holds the testdata of the package-name-quality rule.
+package util // want `package "util" has a meaningless name, name it after what it provides`
@@ -0,0 +1,20 @@
+// Package paniccomment holds the testdata of the panic-comment rule.
+package paniccomment
+
+// MustParse returns the number described by s.
+func MustParse(s string) int { // want `comment for function "MustParse" should document that it panics, as it calls panic at line 7`
+	if s == "" {
+		panic("empty")
+	}
+
+	return len(s)
+}
+
+// MustLoad returns the number described by s, and panics if s is empty.
+func MustLoad(s string) int {
+	if s == "" {
+		panic("empty")
+	}
+
+	return len(s)
+}
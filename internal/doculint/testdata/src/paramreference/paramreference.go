@@ -0,0 +1,8 @@
+// Package paramreference holds the testdata of the param-reference rule.
+package paramreference
+
+// Render returns the HTML of `page`.
+func Render(p string) string { return p } // want `comment for function "Render" refers to "page", which is not a parameter, result, or receiver of the function`
+
+// Paint draws `p`, see [Render].
+func Paint(p string) {}
@@ -0,0 +1,19 @@
+// Package processexit holds the testdata of the process-exit rule.
+package processexit
+
+import (
+	"log"
+	"os"
+)
+
+// Load loads the configuration, and exits the process if it cannot be read.
+func Load(err error) {
+	if err != nil {
+		log.Fatal(err) // want `call to log.Fatal terminates the process and should not be used in library package "processexit"`
+	}
+}
+
+// Quit exits the process with the given code.
+func Quit(code int) {
+	os.Exit(code) // want `call to os.Exit terminates the process and should not be used in library package "processexit"`
+}
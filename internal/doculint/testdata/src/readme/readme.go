@@ -0,0 +1,7 @@
+// Package readme holds the testdata of the readme rule.
+package readme
+
+// Render returns the HTML of the page.
+func Render() string { return "" }
+
+func Paint() {} // want `function "Paint" has no comment associated with it`
@@ -0,0 +1,11 @@
+// Package restatedcomment holds the testdata of the restated-comment rule.
+package restatedcomment
+
+// User is a user of the service.
+type User struct{}
+
+// GetUser gets user.
+func GetUser(id int) User { return User{} } // want `comment for function "GetUser" only restates its declaration, describe its behavior instead`
+
+// LoadUser returns the user with the given id, loading it from the cache when possible.
+func LoadUser(id int) User { return User{} }
@@ -0,0 +1,23 @@
+// Package scriptrule holds the testdata of the rules defined in the configuration file.
+package scriptrule
+
+// Widget is a widget.
+type Widget struct{} // want `comment of type "Widget" does not name its owning team`
+
+// Gadget is a gadget.
+//
+// Owner: gadgets.
+type Gadget struct{}
+
+// Spin spins w.
+func (w Widget) Spin() {}
+
+// Turn turns w.
+//
+// NOTE: same as Spin.
+func (w Widget) Turn() {} // want `function "Turn" is reported by rule ORG002`
+
+// Stop stops w.
+//
+// Note: Spin no longer starts it, so there is nothing left to stop here.
+func (w Widget) Stop() {}
@@ -0,0 +1,8 @@
+// Package spelling holds the testdata of the spelling rule.
+package spelling // want +2 `"recieved" in comment for function "Render" is likely misspelled, did you mean "received"\?`
+
+// Render returns the HTML of the recieved page.
+func Render() string { return "" }
+
+// Paint draws the page.
+func Paint() {}
@@ -0,0 +1,14 @@
+// Package stalereference holds the testdata of the stale-reference rule.
+package stalereference
+
+// Page is a page of widgets.
+type Page struct{}
+
+// Paint draws the page.
+func (p Page) Paint() {}
+
+// Render returns the HTML of the page, see Page.Draw.
+func Render() string { return "" } // want `comment for function "Render" refers to "Page.Draw", but Page has no field or method Draw`
+
+// Print prints the page, see Page.Paint.
+func Print() {}
@@ -0,0 +1,2 @@
+// Package errors holds the testdata of the package-name-stdlib rule.
+package errors // want `package "errors" shadows the standard library package "errors", forcing an import alias on code using both`
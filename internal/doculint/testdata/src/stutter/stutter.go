@@ -0,0 +1,8 @@
+// Package stutter holds the testdata of the stutter rule.
+package stutter
+
+// StutterConfig configures the rule.
+type StutterConfig struct{} // want `type "StutterConfig" repeats the package name, callers will write it as "stutter.StutterConfig"`
+
+// Config configures the rule.
+type Config struct{}
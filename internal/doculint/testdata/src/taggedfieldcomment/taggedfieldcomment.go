@@ -0,0 +1,10 @@
+// Package taggedfieldcomment holds the testdata of the tagged-field-comment rule.
+package taggedfieldcomment
+
+// Widget is a rendered element.
+type Widget struct { // want +1 `field "Name" of type "Widget", serialized through its json tag, has no comment associated with it`
+	Name string `json:"name"`
+
+	// Width is the width of the widget in pixels.
+	Width int `json:"width"`
+}
@@ -0,0 +1,2 @@
+// Package testfunctioncomment holds the testdata of the test-function-comment rule.
+package testfunctioncomment
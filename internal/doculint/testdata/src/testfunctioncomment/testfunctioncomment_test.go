@@ -0,0 +1,12 @@
+package testfunctioncomment
+
+import "testing"
+
+func BenchmarkRender(b *testing.B) {} // want `benchmark "BenchmarkRender" has no comment describing the workload it measures`
+
+// BenchmarkPaint measures painting a page of a thousand widgets.
+func BenchmarkPaint(b *testing.B) {}
+
+func FuzzParse(f *testing.F) {} // want `fuzz test "FuzzParse" has no comment describing the corpus it explores`
+
+func TestRender(t *testing.T) {}
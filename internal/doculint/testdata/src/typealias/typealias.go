@@ -0,0 +1,10 @@
+// Package typealias holds the testdata of the type-alias rule.
+package typealias
+
+import "strings"
+
+// Buffer accumulates text.
+type Buffer = strings.Builder // want `comment for type alias "Buffer" should explain the aliasing by mentioning the aliased type "strings.Builder"`
+
+// Reader is an alias of strings.Reader, kept for compatibility.
+type Reader = strings.Reader
@@ -0,0 +1,16 @@
+// Package typeblockcomment holds the testdata of the type-block-comment rule.
+package typeblockcomment
+
+type ( // want `type block has no comment associated with it`
+	// Widget is a rendered element.
+	Widget struct{}
+
+	// Page is a tree of widgets.
+	Page struct{}
+)
+
+// Elements of a page.
+type (
+	// Button is a clickable widget.
+	Button struct{}
+)
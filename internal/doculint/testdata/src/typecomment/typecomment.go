@@ -0,0 +1,13 @@
+// Package typecomment holds the testdata of the type-comment rule.
+package typecomment
+
+// widget is a rendered element.
+type Widget struct{} // want `comment for type "Widget" should begin with "Widget"`
+
+// Holds the widgets of a page.
+type Page struct{} // want `comment for type "Page" should begin with "Page"`
+
+type Button struct{} // want `type "Button" has no comment associated with it`
+
+// Label is a widget showing text.
+type Label struct{}
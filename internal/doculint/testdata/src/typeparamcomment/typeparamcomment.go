@@ -0,0 +1,11 @@
+// Package typeparamcomment holds the testdata of the type-param-comment rule.
+package typeparamcomment
+
+// Map applies f to every element of s.
+func Map[T, U any](s []T, f func(T) U) []U { return nil } // want `comment for function "Map" should describe its type parameter "T"` `comment for function "Map" should describe its type parameter "U"`
+
+// Filter returns the elements of s, each a T, for which f returns true.
+func Filter[T any](s []T, f func(T) bool) []T { return nil }
+
+// Set is a set of elements.
+type Set[E comparable] map[E]bool // want `comment for type "Set" should describe its type parameter "E"`
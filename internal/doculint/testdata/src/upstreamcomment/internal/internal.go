@@ -0,0 +1,7 @@
+// Package internal holds the declarations exported by the upstream-comment testdata.
+package internal
+
+type Client struct{}
+
+// Server answers the requests of clients.
+type Server struct{}
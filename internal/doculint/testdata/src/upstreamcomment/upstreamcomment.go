@@ -0,0 +1,10 @@
+// Package upstreamcomment holds the testdata of the upstream-comment rule.
+package upstreamcomment
+
+import "upstreamcomment/internal"
+
+// Client is the client of the service.
+type Client = internal.Client // want `type "Client" re-exports "internal.Client", which has no comment associated with it` Client:"documented"
+
+// Server is the server of the service, an alias of internal.Server.
+type Server = internal.Server // want Server:"documented"
@@ -0,0 +1,14 @@
+// Package wellknownmethod holds the testdata of the well-known-method rule.
+package wellknownmethod
+
+// Size is the size of a widget.
+type Size int
+
+// String returns the name.
+func (s Size) String() string { return "" } // want `comment for method "String" should mention the interface it implements, as in "String implements fmt.Stringer"`
+
+// Color is the color of a widget.
+type Color int
+
+// String implements fmt.Stringer, returning the name of c.
+func (c Color) String() string { return "" }
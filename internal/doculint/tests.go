@@ -0,0 +1,99 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// testPrefixes are the prefixes of the names of the functions the go test command runs,
+// mapped to the kind of function they name.
+var testPrefixes = map[string]string{
+	"Test":      "test",
+	"Benchmark": "benchmark",
+	"Fuzz":      "fuzz test",
+	"Example":   "example",
+}
+
+// testFunctionKind returns the kind of function run by the go test command fn is, such
+// as "benchmark" for BenchmarkXxx, or an empty string if fn is not declared in a test
+// file or not such a function. TestMain is considered a test.
+func testFunctionKind(pass *analysis.Pass, fn *ast.FuncDecl) string {
+	if fn.Recv != nil || !strings.HasSuffix(pass.Fset.Position(fn.Pos()).Filename, "_test.go") {
+		return ""
+	}
+
+	for prefix, kind := range testPrefixes {
+		rest, ok := strings.CutPrefix(fn.Name.Name, prefix)
+		if !ok {
+			continue
+		}
+
+		// As with go test, the prefix must not be followed by a lowercase letter, as in
+		// "Testify".
+		if r, _ := utf8.DecodeRuneInString(rest); rest == "" || !unicode.IsLower(r) {
+			return kind
+		}
+	}
+
+	return ""
+}
+
+// checkTestFunctionDoc reports the benchmark or fuzz test fn, of the given kind, if it
+// has no comment beginning with its name when -benchmark-docs or -fuzz-docs is set,
+// since the workload they measure or the corpus they explore is rarely obvious from
+// their names.
+func (l *Linter) checkTestFunctionDoc(pass *analysis.Pass, kind string, fn *ast.FuncDecl) {
+	var subject string
+	switch {
+	case kind == "benchmark" && l.requireBenchmarkDocs:
+		subject = "the workload it measures"
+	case kind == "fuzz test" && l.requireFuzzDocs:
+		subject = "the corpus it explores"
+	default:
+		return
+	}
+
+	if fn.Doc == nil || onlyDirective(fn.Doc) != "" {
+		l.reportRange(pass, RuleTestFunctionComment, fn.Name, "%s \"%s\" has no comment describing %s", kind, fn.Name.Name, subject)
+		return
+	}
+
+	if !strings.HasPrefix(strings.TrimSpace(fn.Doc.Text()), fn.Name.Name) {
+		l.reportPrefix(pass, RuleTestFunctionComment, fn.Name, fn.Doc, fn.Name.Name, "comment for %s \"%s\" should begin with \"%s\"", kind, fn.Name.Name, fn.Name.Name)
+	}
+
+	l.checkDoc(pass, kindFunction, fmt.Sprintf("%s \"%s\"", kind, fn.Name.Name), fn.Name.Name, fn.Pos(), fn.Doc)
+}
+
+// isTestHelper reports whether fn is a test helper, which is a function declared in a
+// test file, other than the ones run by go test, taking a *testing.T, *testing.B,
+// *testing.F, or testing.TB as its first parameter.
+func isTestHelper(pass *analysis.Pass, fn *ast.FuncDecl) bool {
+	params := fn.Type.Params.List
+	if len(params) == 0 || !strings.HasSuffix(pass.Fset.Position(fn.Pos()).Filename, "_test.go") {
+		return false
+	}
+
+	t := pass.TypesInfo.TypeOf(params[0].Type)
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "testing" {
+		return false
+	}
+
+	switch named.Obj().Name() {
+	case "T", "B", "F", "TB":
+		return true
+	}
+
+	return false
+}
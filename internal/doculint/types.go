@@ -0,0 +1,325 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkTypeDecl validates the comments of a type declaration, which is either a single
+// type or a block of types, according to the type block settings of the package.
+func (l *Linter) checkTypeDecl(pass *analysis.Pass, settings packageSettings, decl *ast.GenDecl) {
+	block := decl.Lparen.IsValid()
+	if block && settings.exemptSingleTypeBlocks && len(decl.Specs) == 1 {
+		// Treat the block as if its only type was not in a block, gofmt and
+		// refactoring tools commonly leave these behind.
+		block = false
+	}
+
+	blockDocumented := false
+	if block {
+		blockDocumented = decl.Doc != nil && onlyDirective(decl.Doc) == ""
+
+		if !blockDocumented && (settings.typeBlocks == blockModeStrict || !allTypesDocumented(decl)) {
+			l.reportRange(pass, RuleTypeBlockComment, keyword(decl), "type block has no comment associated with it")
+		}
+
+		l.checkDoc(pass, kindType, "type block", "", decl.Pos(), decl.Doc)
+	}
+
+	for i := range decl.Specs {
+		ts, ok := decl.Specs[i].(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		l.checkEmbeddedFields(pass, ts)
+		l.checkConfigFields(pass, ts)
+		l.checkTaggedFields(pass, ts)
+
+		what := "type"
+		if ts.Assign.IsValid() {
+			what = "type alias"
+		}
+
+		doc := ts.Doc
+		if !decl.Lparen.IsValid() {
+			// If this type isn't apart of a type block it's comment is stored in the *ast.GenDecl type.
+			doc = decl.Doc
+		} else if !block && doc == nil {
+			// A single type block exempted from block rules may be documented either way.
+			doc = decl.Doc
+		}
+
+		if doc == nil {
+			if settings.typeBlocks == blockModeRelaxed && blockDocumented {
+				// The comment of the block documents the types within it.
+				continue
+			}
+
+			l.reportMissingDoc(pass, RuleTypeComment, ts.Name, declStart(decl, ts), stubData{Name: ts.Name.Name, Kind: kindType}, "%s \"%s\" has no comment associated with it", what, ts.Name.Name)
+			continue
+		}
+
+		if directive := onlyDirective(doc); directive != "" {
+			l.reportRange(pass, RuleTypeComment, doc, "%s \"%s\" has no comment associated with it, only the directive \"%s\", which is not documentation", what, ts.Name.Name, directive)
+			continue
+		}
+
+		if !strings.HasPrefix(strings.TrimSpace(doc.Text()), ts.Name.Name) {
+			l.reportPrefix(pass, RuleTypeComment, ts.Name, doc, ts.Name.Name, "comment for %s \"%s\" should begin with \"%s\"", what, ts.Name.Name, ts.Name.Name)
+		}
+
+		described := fmt.Sprintf("%s \"%s\"", what, ts.Name.Name)
+		l.checkDoc(pass, kindType, described, ts.Name.Name, ts.Pos(), doc)
+		l.checkTypeParams(pass, described, ts.Pos(), ts.TypeParams, doc)
+		l.checkRestated(pass, described, ts.Name.Name, ts.Pos(), doc, nil)
+		l.checkInterfaceContract(pass, ts, doc)
+		if l.checkStaleRefs {
+			l.checkStaleReferences(pass, described, ts.Pos(), doc, typeLocalNames(ts), typeOf(pass, ts))
+		}
+
+		if ts.Assign.IsValid() {
+			l.checkAliasComment(pass, ts, doc)
+		}
+	}
+}
+
+// checkAliasComment reports the comment doc of the type alias ts if it explains the
+// aliasing neither by mentioning the aliased type nor by using the word "alias".
+func (l *Linter) checkAliasComment(pass *analysis.Pass, ts *ast.TypeSpec, doc *ast.CommentGroup) {
+	text := doc.Text()
+	if strings.Contains(strings.ToLower(text), "alias") {
+		return
+	}
+
+	target := types.ExprString(ts.Type)
+	if strings.Contains(text, target) || containsWord(text, aliasedName(ts.Type)) {
+		return
+	}
+
+	l.report(pass, RuleTypeAlias, ts.Pos(), "comment for type alias \"%s\" should explain the aliasing by mentioning the aliased type \"%s\"", ts.Name.Name, target)
+}
+
+// aliasedName returns the name of the type expression expr without its package
+// qualifier, pointer, or type arguments, such as "Reader" for "*io.Reader", or an empty
+// string for type literals.
+func aliasedName(expr ast.Expr) string {
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ParenExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.SelectorExpr:
+			return t.Sel.Name
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// checkEmbeddedFields reports the embedded fields of ts, if it is an exported struct
+// type, that have no comment explaining why they are embedded, when -embedded-docs is
+// set, since the behavior promoted by embeddings frequently confuses API consumers.
+func (l *Linter) checkEmbeddedFields(pass *analysis.Pass, ts *ast.TypeSpec) {
+	st, ok := ts.Type.(*ast.StructType)
+	if !l.requireEmbeddedDocs || !ok || !ts.Name.IsExported() {
+		return
+	}
+
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 && field.Doc == nil && field.Comment == nil {
+			l.report(pass, RuleEmbeddedComment, field.Pos(), "field \"%s\" embedded in type \"%s\" should have a comment explaining the behavior it promotes", types.ExprString(field.Type), ts.Name.Name)
+		}
+	}
+}
+
+// defaultWords are the words, matched case insensitively as prefixes, that the comment
+// of a field of a configuration struct can use to document its default value.
+var defaultWords = []string{"default", "zero", "unset", "empty"}
+
+// checkConfigFields reports the exported fields of ts, if it is an exported struct type
+// named with one of the suffixes in -config-suffixes, such as ClientOptions, whose
+// comment is missing or does not document the default value of the field, when
+// -config-fields is set. These types are the de facto configuration surface of an API,
+// whose users need to know what leaving a field unset does.
+func (l *Linter) checkConfigFields(pass *analysis.Pass, ts *ast.TypeSpec) {
+	st, ok := ts.Type.(*ast.StructType)
+	if !l.requireConfigFieldDocs || !ok || !ts.Name.IsExported() || !l.isConfigTypeName(ts.Name.Name) {
+		return
+	}
+
+	for _, field := range st.Fields.List {
+		doc := field.Doc
+		if doc == nil {
+			doc = field.Comment
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+
+			if doc == nil {
+				l.reportRange(pass, RuleConfigField, name, "field \"%s\" of configuration type \"%s\" has no comment associated with it", name.Name, ts.Name.Name)
+				continue
+			}
+
+			if !mentionsDefault(doc.Text()) {
+				l.reportRange(pass, RuleConfigField, doc, "comment for field \"%s\" of configuration type \"%s\" should document its default value", name.Name, ts.Name.Name)
+			}
+		}
+	}
+}
+
+// checkTaggedFields reports the exported fields of the structs within ts that carry a
+// struct tag with one of the keys in -tagged-field-docs, such as json, and have no
+// comment, or a comment made only of their name or serialized name. These structs
+// define wire formats consumed by other teams, who only have the comments to go by.
+// Fields excluded from serialization, with a tag value of "-", are ignored.
+func (l *Linter) checkTaggedFields(pass *analysis.Pass, ts *ast.TypeSpec) {
+	if len(l.taggedFieldKeys) == 0 {
+		return
+	}
+
+	ast.Inspect(ts.Type, func(n ast.Node) bool {
+		st, ok := n.(*ast.StructType)
+		if !ok {
+			return true
+		}
+
+		for _, field := range st.Fields.List {
+			key, value := l.serializationTag(field)
+			if key == "" {
+				continue
+			}
+
+			doc := field.Doc
+			if doc == nil {
+				doc = field.Comment
+			}
+
+			for _, name := range field.Names {
+				if !name.IsExported() {
+					continue
+				}
+
+				if doc == nil {
+					l.reportRange(pass, RuleTaggedFieldComment, name, "field \"%s\" of type \"%s\", serialized through its %s tag, has no comment associated with it", name.Name, ts.Name.Name, key)
+					continue
+				}
+
+				text := strings.Trim(strings.TrimSpace(doc.Text()), terminalPunctuation)
+				if strings.EqualFold(text, name.Name) || strings.EqualFold(text, value) {
+					l.reportRange(pass, RuleTaggedFieldComment, doc, "comment for field \"%s\" of type \"%s\", serialized through its %s tag, should describe the field rather than repeat its name", name.Name, ts.Name.Name, key)
+				}
+			}
+		}
+
+		return true
+	})
+}
+
+// serializationTag returns the first key of -tagged-field-docs found in the struct tag
+// of field, along with the serialized name of the field, or empty strings if field has
+// none or is excluded from serialization.
+func (l *Linter) serializationTag(field *ast.Field) (string, string) {
+	if field.Tag == nil {
+		return "", ""
+	}
+
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", ""
+	}
+
+	for _, key := range l.taggedFieldKeys {
+		value, ok := reflect.StructTag(tag).Lookup(key)
+		if !ok {
+			continue
+		}
+
+		name, _, _ := strings.Cut(value, ",")
+		if name == "-" {
+			return "", ""
+		}
+
+		return key, name
+	}
+
+	return "", ""
+}
+
+// isConfigTypeName reports whether name ends with one of the suffixes in
+// -config-suffixes.
+func (l *Linter) isConfigTypeName(name string) bool {
+	for _, suffix := range l.configSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mentionsDefault reports whether text contains a word beginning with one of
+// defaultWords, documenting a default value.
+func mentionsDefault(text string) bool {
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), isNotWordRune) {
+		for _, prefix := range defaultWords {
+			if strings.HasPrefix(word, prefix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// typeLocalNames returns the names of the type parameters of ts, along with the name of
+// ts itself.
+func typeLocalNames(ts *ast.TypeSpec) map[string]bool {
+	names := map[string]bool{ts.Name.Name: true}
+	if ts.TypeParams != nil {
+		for _, field := range ts.TypeParams.List {
+			for _, name := range field.Names {
+				names[name.Name] = true
+			}
+		}
+	}
+
+	return names
+}
+
+// typeOf returns the type declared by ts, or nil if it is unknown.
+func typeOf(pass *analysis.Pass, ts *ast.TypeSpec) types.Type {
+	if obj := pass.TypesInfo.Defs[ts.Name]; obj != nil {
+		return obj.Type()
+	}
+
+	return nil
+}
+
+// allTypesDocumented reports whether every type within the type block decl has a
+// comment of its own.
+func allTypesDocumented(decl *ast.GenDecl) bool {
+	for i := range decl.Specs {
+		if ts, ok := decl.Specs[i].(*ast.TypeSpec); ok && ts.Doc == nil {
+			return false
+		}
+	}
+
+	return true
+}
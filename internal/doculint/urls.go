@@ -0,0 +1,159 @@
+package doculint
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// urlTimeout is how long a request checking the reachability of a URL may take.
+const urlTimeout = 10 * time.Second
+
+// urlPattern matches the URLs of a comment, which go/doc/comment links in godoc.
+var urlPattern = regexp.MustCompile(`\b(?:https?|ftp|file|gopher|mailto|nntp)://\S+`)
+
+// urlTrailingPunctuation contains the characters go/doc/comment does not consider part
+// of a URL when found at its end.
+const urlTrailingPunctuation = ".,:;?!'\""
+
+// checkURLs reports the URLs found in the doc comment doc of a declaration described by
+// what that are malformed, such as those truncated by a line break, and, with
+// -url-reachability, those that cannot be fetched.
+func (l *Linter) checkURLs(pass *analysis.Pass, what string, doc *ast.CommentGroup) {
+	if !l.checkDocURLs {
+		return
+	}
+
+	for _, c := range doc.List {
+		if isDirective(c.Text) {
+			continue
+		}
+
+		for _, loc := range urlPattern.FindAllStringIndex(c.Text, -1) {
+			raw := trimURL(c.Text[loc[0]:loc[1]])
+			pos := c.Pos() + token.Pos(loc[0])
+
+			problem := urlProblem(raw)
+			if problem == "" && l.checkURLReachability {
+				problem = l.unreachable(raw)
+			}
+
+			if problem != "" {
+				l.reportDiagnostic(pass, RuleDocURL, analysis.Diagnostic{
+					Pos:     pos,
+					End:     pos + token.Pos(len(raw)),
+					Message: fmt.Sprintf("URL \"%s\" in comment for %s %s", raw, what, problem),
+				})
+			}
+		}
+	}
+}
+
+// trimURL returns raw without the trailing punctuation and unbalanced closing
+// parentheses that go/doc/comment does not consider part of a URL.
+func trimURL(raw string) string {
+	for raw != "" {
+		last := raw[len(raw)-1]
+		switch {
+		case strings.IndexByte(urlTrailingPunctuation, last) >= 0:
+			raw = raw[:len(raw)-1]
+		case last == ')' && strings.Count(raw, "(") < strings.Count(raw, ")"):
+			raw = raw[:len(raw)-1]
+		default:
+			return raw
+		}
+	}
+
+	return raw
+}
+
+// urlProblem returns a description of why raw is not a valid URL, or an empty string
+// if it is one. Besides failing to parse, URLs are invalid if their host is missing or
+// is neither localhost, an IP address, nor a domain name with a top-level domain, which
+// is usually the sign of a URL truncated by a line break.
+func urlProblem(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "is malformed: " + strings.TrimPrefix(err.Error(), fmt.Sprintf("parse %q: ", raw))
+	}
+
+	if u.Scheme == "mailto" || u.Scheme == "file" {
+		return ""
+	}
+
+	host := u.Hostname()
+	switch {
+	case host == "":
+		return "has no host"
+	case host == "localhost" || net.ParseIP(host) != nil:
+		return ""
+	case !strings.Contains(strings.Trim(host, "."), "."):
+		return fmt.Sprintf("has host \"%s\" without a top-level domain, it may be truncated", host)
+	}
+
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Sprintf("has invalid host \"%s\"", host)
+		}
+	}
+
+	return ""
+}
+
+// unreachable returns a description of why the http or https URL raw cannot be
+// fetched, or an empty string if it can be or uses another scheme. A HEAD request is
+// made first, falling back to a GET request for servers that do not support it.
+func (l *Linter) unreachable(raw string) string {
+	if !strings.HasPrefix(raw, "http://") && !strings.HasPrefix(raw, "https://") {
+		return ""
+	}
+
+	if problem, ok := l.reachability.Load(raw); ok {
+		return problem.(string)
+	}
+
+	problem := ""
+	status, err := fetchStatus(http.MethodHead, raw)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = fetchStatus(http.MethodGet, raw)
+	}
+
+	switch {
+	case err != nil:
+		problem = "is unreachable: " + err.Error()
+	case status >= http.StatusBadRequest:
+		problem = fmt.Sprintf("is unreachable: %d %s", status, http.StatusText(status))
+	}
+
+	l.reachability.Store(raw, problem)
+	return problem
+}
+
+// fetchStatus requests raw with the given method and returns the status code of the
+// response.
+func fetchStatus(method, raw string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), urlTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, raw, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
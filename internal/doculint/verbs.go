@@ -0,0 +1,116 @@
+package doculint
+
+import (
+	"go/ast"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// verbs are the present tense verbs accepted after the name of a function that do not
+// end in "s", such as modal verbs.
+var verbs = map[string]bool{
+	"can":    true,
+	"could":  true,
+	"may":    true,
+	"might":  true,
+	"must":   true,
+	"should": true,
+	"will":   true,
+	"would":  true,
+}
+
+// nonVerbs are words ending in "s" that are not present tense verbs.
+var nonVerbs = map[string]bool{
+	"as":      true,
+	"its":     true,
+	"perhaps": true,
+	"plus":    true,
+	"this":    true,
+	"thus":    true,
+	"was":     true,
+	"whereas": true,
+}
+
+// sentenceVerbs are the verbs, besides the present tense verbs in the third person,
+// that make a sentence complete, such as "are" in "Options are the settings of Foo.".
+var sentenceVerbs = map[string]bool{
+	"am":   true,
+	"are":  true,
+	"be":   true,
+	"do":   true,
+	"had":  true,
+	"have": true,
+	"was":  true,
+	"were": true,
+}
+
+// adverbs are the adverbs, besides those ending in "ly", that may come between the name
+// of a function and its verb, as in "Foo always returns".
+var adverbs = map[string]bool{
+	"also":   true,
+	"always": true,
+	"first":  true,
+	"never":  true,
+	"now":    true,
+	"often":  true,
+	"then":   true,
+}
+
+// checkVerb reports the comment of the function fn, which begins with the name of the
+// function, if -verbs is set and the word following the name is not a present tense
+// verb, such as in "Foo the thing", since it reads poorly in godoc.
+func (l *Linter) checkVerb(pass *analysis.Pass, fn *ast.FuncDecl) {
+	if !l.requireVerbs {
+		return
+	}
+
+	rest := strings.TrimPrefix(strings.TrimSpace(fn.Doc.Text()), fn.Name.Name)
+	if r, _ := utf8.DecodeRuneInString(rest); r != ' ' {
+		// Comments such as "Foo." or "Foo's" have no verb to check.
+		return
+	}
+
+	words := strings.Fields(firstSentence(rest))
+	for len(words) > 1 && isAdverb(words[0]) {
+		words = words[1:]
+	}
+
+	if len(words) == 0 {
+		return
+	}
+
+	if word := strings.TrimRightFunc(words[0], unicode.IsPunct); !isPresentTenseVerb(word) {
+		l.report(pass, RuleFunctionVerb, fn.Pos(), "comment for function \"%s\" should continue with a verb, as in \"%s returns\", rather than \"%s\"", fn.Name.Name, fn.Name.Name, word)
+	}
+}
+
+// isAdverb reports whether word is an adverb that may precede a verb.
+func isAdverb(word string) bool {
+	word = strings.ToLower(word)
+	return adverbs[word] || strings.HasSuffix(word, "ly")
+}
+
+// isPresentTenseVerb reports whether word is likely a present tense verb in the third
+// person, meaning it is either a known verb or ends in "s" but not "ss" or "us".
+func isPresentTenseVerb(word string) bool {
+	word = strings.ToLower(word)
+	if verbs[word] {
+		return true
+	}
+
+	if nonVerbs[word] || strings.HasSuffix(word, "ss") || strings.HasSuffix(word, "us") {
+		return false
+	}
+
+	return strings.HasSuffix(word, "s")
+}
+
+// isSentenceVerb reports whether word is likely a verb making a sentence complete,
+// meaning it is either a present tense verb in the third person, as reported by
+// isPresentTenseVerb, or another common verb, such as "are".
+func isSentenceVerb(word string) bool {
+	return sentenceVerbs[strings.ToLower(word)] || isPresentTenseVerb(word)
+}
@@ -0,0 +1,133 @@
+package doculint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Modes for how the methods implementing well-known interfaces are documented.
+const (
+	// methodModeStrict requires well-known methods to be documented as any other.
+	methodModeStrict = "strict"
+
+	// methodModeRelaxed exempts well-known methods from needing a comment.
+	methodModeRelaxed = "relaxed"
+
+	// methodModeImplements requires the comments of well-known methods to mention the
+	// interface they implement.
+	methodModeImplements = "implements"
+)
+
+// methodMode is a mode for how well-known methods are documented, used as a
+// flag.Value.
+type methodMode string
+
+// String returns the name of the mode.
+func (m *methodMode) String() string {
+	return string(*m)
+}
+
+// Set sets the mode from its name.
+func (m *methodMode) Set(s string) error {
+	switch s {
+	case methodModeStrict, methodModeRelaxed, methodModeImplements:
+		*m = methodMode(s)
+		return nil
+	}
+
+	return fmt.Errorf("unknown well-known method mode \"%s\", expected %s, %s, or %s", s, methodModeStrict, methodModeRelaxed, methodModeImplements)
+}
+
+// UnmarshalText sets the mode from its name, validating modes read from the
+// configuration file.
+func (m *methodMode) UnmarshalText(text []byte) error {
+	return m.Set(string(text))
+}
+
+// wellKnownMethod is a method implementing a well-known interface.
+type wellKnownMethod struct {
+	// signature is the signature of the method, without parameter names, as returned
+	// by signatureString.
+	signature string
+
+	// iface is the qualified name of the interface implemented by the method.
+	iface string
+}
+
+// wellKnownMethods maps the names of the methods implementing well-known interfaces to
+// their signature and interface.
+var wellKnownMethods = map[string]wellKnownMethod{
+	"String":          {"() string", "fmt.Stringer"},
+	"GoString":        {"() string", "fmt.GoStringer"},
+	"Format":          {"(fmt.State, rune)", "fmt.Formatter"},
+	"Error":           {"() string", "error"},
+	"MarshalJSON":     {"() ([]byte, error)", "json.Marshaler"},
+	"UnmarshalJSON":   {"([]byte) error", "json.Unmarshaler"},
+	"MarshalText":     {"() ([]byte, error)", "encoding.TextMarshaler"},
+	"UnmarshalText":   {"([]byte) error", "encoding.TextUnmarshaler"},
+	"MarshalBinary":   {"() ([]byte, error)", "encoding.BinaryMarshaler"},
+	"UnmarshalBinary": {"([]byte) error", "encoding.BinaryUnmarshaler"},
+	"Len":             {"() int", "sort.Interface"},
+	"Less":            {"(int, int) bool", "sort.Interface"},
+	"Swap":            {"(int, int)", "sort.Interface"},
+	"ServeHTTP":       {"(net/http.ResponseWriter, *net/http.Request)", "http.Handler"},
+}
+
+// wellKnownInterface returns the qualified name of the well-known interface
+// implemented by the method fn, such as "fmt.Stringer" for String() string, or an
+// empty string if fn is not such a method.
+func wellKnownInterface(pass *analysis.Pass, fn *ast.FuncDecl) string {
+	method, ok := wellKnownMethods[fn.Name.Name]
+	if !ok || fn.Recv == nil {
+		return ""
+	}
+
+	obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func)
+	if !ok || signatureString(obj.Signature()) != method.signature {
+		return ""
+	}
+
+	return method.iface
+}
+
+// signatureString returns the parameter and result types of sig, without their names,
+// as in "(int, int) bool". Types are qualified by the import path of their package.
+func signatureString(sig *types.Signature) string {
+	tuple := func(t *types.Tuple) []string {
+		s := make([]string, t.Len())
+		for i := range s {
+			s[i] = types.TypeString(t.At(i).Type(), nil)
+		}
+		return s
+	}
+
+	s := "(" + strings.Join(tuple(sig.Params()), ", ") + ")"
+	switch results := tuple(sig.Results()); len(results) {
+	case 0:
+	case 1:
+		s += " " + results[0]
+	default:
+		s += " (" + strings.Join(results, ", ") + ")"
+	}
+
+	return s
+}
+
+// checkWellKnownMethod validates the comment of the method fn implementing the
+// well-known interface iface when the well-known methods of the package are documented
+// in implements mode, which requires the comment to mention the interface, either
+// qualified, such as fmt.Stringer, or not.
+func (l *Linter) checkWellKnownMethod(pass *analysis.Pass, fn *ast.FuncDecl, iface string) {
+	text := fn.Doc.Text()
+
+	_, name, _ := strings.Cut(iface, ".")
+	if strings.Contains(text, iface) || name != "" && containsWord(text, name) {
+		return
+	}
+
+	l.report(pass, RuleWellKnownMethod, fn.Pos(), "comment for method \"%s\" should mention the interface it implements, as in \"%s implements %s\"", fn.Name.Name, fn.Name.Name, iface)
+}
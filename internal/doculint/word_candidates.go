@@ -0,0 +1,117 @@
+//go:build ignore
+
+// word_candidates prints the words of the comments of the Go standard library found in
+// GOROOT that are missing from words.txt, the built-in word list of the spellchecker,
+// and from words_rejected.txt, the words reviewed and left out of it, as candidates to
+// review before adding them to either by hand. Only the words found in the comments of
+// at least minPackages packages are printed, along with the number of those packages,
+// which leaves out most of the misspellings, identifiers, and names of people found in
+// comments. Words without vowels, and words repeating a letter three times in a row,
+// such as "aaaaaaaabbbbbbbbcccccccc", are left out too.
+package main
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// minPackages is the number of packages whose comments a word must be found in to be a
+// candidate.
+const minPackages = 3
+
+// wordPattern matches the lower case words of a comment, excluding parts of
+// identifiers, paths, and contractions.
+var wordPattern = regexp.MustCompile(`(?:^|[^\w'./-])([a-z]{2,})(?:$|[^\w'/-])`)
+
+// vowelPattern matches the words holding a vowel, or a y as in "by".
+var vowelPattern = regexp.MustCompile(`[aeiouy]`)
+
+func main() {
+	known := make(map[string]bool)
+	for _, name := range []string{"words.txt", "words_rejected.txt"} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, word := range strings.Fields(string(data)) {
+			known[word] = true
+		}
+	}
+
+	// packages maps every word to the directories of the packages whose comments it was
+	// found in.
+	packages := make(map[string]map[string]bool)
+
+	root := filepath.Join(runtime.GOROOT(), "src")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() && (d.Name() == "testdata" || d.Name() == "vendor") {
+			return filepath.SkipDir
+		}
+
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil
+		}
+
+		for _, cg := range file.Comments {
+			for _, line := range strings.Split(cg.Text(), "\n") {
+				// Add a space so that adjacent words can share their separator.
+				line = strings.ReplaceAll(line, " ", "  ")
+
+				for _, m := range wordPattern.FindAllStringSubmatch(line, -1) {
+					word := m[1]
+					if packages[word] == nil {
+						packages[word] = make(map[string]bool)
+					}
+					packages[word][filepath.Dir(path)] = true
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var candidates []string
+	for word, dirs := range packages {
+		if !known[word] && len(dirs) >= minPackages && vowelPattern.MatchString(word) && !repeatsLetter(word) {
+			candidates = append(candidates, word)
+		}
+	}
+	sort.Strings(candidates)
+
+	for _, word := range candidates {
+		fmt.Printf("%s\t%d\n", word, len(packages[word]))
+	}
+}
+
+// repeatsLetter reports whether word repeats a letter three times in a row.
+func repeatsLetter(word string) bool {
+	for i := 2; i < len(word); i++ {
+		if word[i] == word[i-1] && word[i] == word[i-2] {
+			return true
+		}
+	}
+
+	return false
+}